@@ -0,0 +1,185 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// UTCTimeFormat is to denote the format is an ASN.1 UTCTime such as "210308160634Z" (X.509 validity before 2050)
+	UTCTimeFormat = "ASN.1 UTCTime"
+
+	// GeneralizedTimeFormat is to denote the format is an ASN.1 GeneralizedTime such as "20210308160634.5Z" (LDAP, Kerberos)
+	GeneralizedTimeFormat = "ASN.1 GeneralizedTime"
+
+	regExUTCTime         = `^(\d\d)(\d\d)(\d\d)(\d\d)(\d\d)(\d\d)?(Z|[+-]\d{4})$`
+	regExGeneralizedTime = `^(\d{4})(\d\d)(\d\d)(\d\d)(\d\d)?(\d\d)?([.,]\d+)?(Z|[+-]\d\d(\d\d)?)?$`
+)
+
+var (
+	reUTCTime         = regexp.MustCompile(regExUTCTime)
+	reGeneralizedTime = regexp.MustCompile(regExGeneralizedTime)
+)
+
+// GetASN1TimeFormat determines if the provided string is an ASN.1 UTCTime or
+// GeneralizedTime. A ten digit value with a zone such as 2021030816Z is a
+// GeneralizedTime (to the hour) when its UTCTime fields are out of range. A
+// GeneralizedTime needs a zone or fraction to be told apart from an integer,
+// and with only a fraction it needs at least the minutes and valid fields to
+// be told apart from a decimal UNIX timestamp.
+func GetASN1TimeFormat(dtz string) (format string) {
+	m := reGeneralizedTime.FindStringSubmatch(dtz)
+	switch {
+	case reUTCTime.MatchString(dtz):
+		format = UTCTimeFormat
+		if _, err := ParseUTCTime(dtz); err != nil && m != nil {
+			if _, err = ParseGeneralizedTime(dtz); err == nil {
+				format = GeneralizedTimeFormat
+			}
+		}
+	case m == nil:
+	case len(m[8]) > 0:
+		format = GeneralizedTimeFormat
+	case len(m[7]) > 0 && len(m[5]) > 0:
+		if _, err := ParseGeneralizedTime(dtz); err == nil {
+			format = GeneralizedTimeFormat
+		}
+	}
+	if len(format) > 0 {
+		DebugPrintf("chronus.GetASN1TimeFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// asn1Zone returns the location for a Z or ±hh[mm] suffix, or DefaultLocation
+// (nil is UTC) when there is none
+func asn1Zone(zone string) (*time.Location, error) {
+	switch {
+	case zone == "":
+		return defaultLocation(), nil
+	case zone == "Z":
+		return time.UTC, nil
+	}
+	hh, _ := strconv.Atoi(zone[1:3])
+	mm := 0
+	if len(zone) == 5 {
+		mm, _ = strconv.Atoi(zone[3:])
+	}
+	if hh > 23 || mm > 59 {
+		return nil, fmt.Errorf("zone offset %q out of range", zone)
+	}
+	offset := hh*3600 + mm*60
+	if zone[0] == '-' {
+		offset = -offset
+	}
+	return time.FixedZone("", offset), nil
+}
+
+// asn1Date builds the time checking each field is in range
+func asn1Date(year, month, day, hour, minute, second int, loc *time.Location) (t time.Time, err error) {
+	if month < 1 || month > 12 || hour > 23 || minute > 59 || second > 60 {
+		return t, fmt.Errorf("field out of range")
+	}
+	t = time.Date(year, time.Month(month), day, hour, minute, 0, 0, loc)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("day %d is not in %s", day, time.Month(month))
+	}
+	// a leap second (:60) rolls over into the next minute
+	return t.Add(time.Duration(second) * time.Second), nil
+}
+
+// ParseUTCTime parses an ASN.1 UTCTime (YYMMDDhhmm[ss]Z or with a ±hhmm offset).
+// Two digit years 50-99 are 1950-1999 and 00-49 are 2000-2049 as RFC 5280 specifies.
+func ParseUTCTime(s string) (t time.Time, err error) {
+	m := reUTCTime.FindStringSubmatch(s)
+	if m == nil {
+		return t, fmt.Errorf("invalid ASN.1 UTCTime %q", s)
+	}
+	loc, err := asn1Zone(m[7])
+	if err != nil {
+		return t, fmt.Errorf("invalid ASN.1 UTCTime %q: %s", s, err.Error())
+	}
+	n := make([]int, 7)
+	for i := 1; i <= 6; i++ {
+		n[i], _ = strconv.Atoi(m[i])
+	}
+	year := 1900 + n[1]
+	if n[1] < 50 {
+		year = 2000 + n[1]
+	}
+	if t, err = asn1Date(year, n[2], n[3], n[4], n[5], n[6], loc); err != nil {
+		return t, fmt.Errorf("invalid ASN.1 UTCTime %q: %s", s, err.Error())
+	}
+
+	return t, nil
+}
+
+// ParseGeneralizedTime parses an ASN.1 GeneralizedTime (YYYYMMDDhh[mm[ss]]) with
+// an optional fraction (of the last field given) and an optional Z or ±hh[mm]
+// zone. Without a zone it is local time in DefaultLocation (nil is UTC).
+func ParseGeneralizedTime(s string) (t time.Time, err error) {
+	m := reGeneralizedTime.FindStringSubmatch(s)
+	if m == nil {
+		return t, fmt.Errorf("invalid ASN.1 GeneralizedTime %q", s)
+	}
+	loc, err := asn1Zone(m[8])
+	if err != nil {
+		return t, fmt.Errorf("invalid ASN.1 GeneralizedTime %q: %s", s, err.Error())
+	}
+	n := make([]int, 7)
+	for i := 1; i <= 6; i++ {
+		n[i], _ = strconv.Atoi(m[i])
+	}
+	if t, err = asn1Date(n[1], n[2], n[3], n[4], n[5], n[6], loc); err != nil {
+		return t, fmt.Errorf("invalid ASN.1 GeneralizedTime %q: %s", s, err.Error())
+	}
+
+	if len(m[7]) > 1 {
+		unit := time.Second
+		switch {
+		case m[5] == "":
+			unit = time.Hour
+		case m[6] == "":
+			unit = time.Minute
+		}
+		frac := m[7][1:]
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		ns, _ := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		t = t.Add(time.Duration(ns) * (unit / time.Second))
+	}
+
+	return t, nil
+}
+
+// ParseASN1Time parses either an ASN.1 UTCTime or GeneralizedTime
+func ParseASN1Time(s string) (t time.Time, err error) {
+	switch GetASN1TimeFormat(s) {
+	case UTCTimeFormat:
+		return ParseUTCTime(s)
+	case GeneralizedTimeFormat:
+		return ParseGeneralizedTime(s)
+	}
+	return t, fmt.Errorf("invalid ASN.1 time %q", s)
+}
+
+// FormatUTCTime returns t as a DER UTCTime such as "210308230634Z", which can
+// only represent 1950 through 2049
+func FormatUTCTime(t time.Time) (string, error) {
+	t = t.UTC()
+	if t.Year() < 1950 || t.Year() > 2049 {
+		return "", fmt.Errorf("year %d is outside the UTCTime range 1950-2049", t.Year())
+	}
+	return t.Format("060102150405Z"), nil
+}
+
+// FormatGeneralizedTime returns t as a DER GeneralizedTime such as
+// "20210308230634.5Z" (UTC, with trailing fractional zeros removed)
+func FormatGeneralizedTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.999999999Z")
+}
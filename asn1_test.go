@@ -0,0 +1,64 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseASN1Time(t *testing.T) {
+	defer func(loc *time.Location) { DefaultLocation = loc }(DefaultLocation)
+	DefaultLocation = time.UTC
+
+	tests := []struct {
+		s      string
+		format string
+		want   string
+	}{
+		{"210308160634Z", UTCTimeFormat, "2021-03-08T16:06:34Z"},
+		{"7001010000Z", UTCTimeFormat, "1970-01-01T00:00:00Z"},
+		{"490101000000-0700", UTCTimeFormat, "2049-01-01T00:00:00-07:00"},
+		{"20210308160634Z", GeneralizedTimeFormat, "2021-03-08T16:06:34Z"},
+		{"20210308160634.5Z", GeneralizedTimeFormat, "2021-03-08T16:06:34.5Z"},
+		{"2021030816Z", GeneralizedTimeFormat, "2021-03-08T16:00:00Z"},
+		{"2021030816.5Z", GeneralizedTimeFormat, "2021-03-08T16:30:00Z"},
+		{"202103081606,25+0100", GeneralizedTimeFormat, "2021-03-08T16:06:15+01:00"},
+		{"20210308160634.5", GeneralizedTimeFormat, "2021-03-08T16:06:34.5Z"},
+		{"20161231235960Z", GeneralizedTimeFormat, "2017-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		if got := GetASN1TimeFormat(tt.s); got != tt.format {
+			t.Errorf("GetASN1TimeFormat(%q) = %q, want %q", tt.s, got, tt.format)
+		}
+		if got, _ := GetFormat(tt.s); got != tt.format {
+			t.Errorf("GetFormat(%q) = %q, want %q", tt.s, got, tt.format)
+		}
+		got, err := ParseASN1Time(tt.s)
+		if err != nil {
+			t.Errorf("ParseASN1Time(%q) error: %s", tt.s, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseASN1Time(%q) = %s, want %s", tt.s, s, tt.want)
+		}
+	}
+
+	// decimal UNIX timestamps are not GeneralizedTimes
+	for _, s := range []string{"1615219594.5", "2021030816.5", "1615219594123.5", "20210308160634"} {
+		if got := GetASN1TimeFormat(s); got != "" {
+			t.Errorf("GetASN1TimeFormat(%q) = %q, want none", s, got)
+		}
+	}
+}
+
+func TestFormatASN1Time(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 500000000, time.FixedZone("", -7*3600))
+	if got, err := FormatUTCTime(ts); err != nil || got != "210308230634Z" {
+		t.Errorf("FormatUTCTime = %q, %v, want 210308230634Z", got, err)
+	}
+	if got := FormatGeneralizedTime(ts); got != "20210308230634.5Z" {
+		t.Errorf("FormatGeneralizedTime = %q, want 20210308230634.5Z", got)
+	}
+	if _, err := FormatUTCTime(time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("FormatUTCTime(2050) should fail")
+	}
+}
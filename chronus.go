@@ -163,8 +163,40 @@ var (
 	debug               = false
 	reIsOffset          = regexp.MustCompile(`[+-]?\d{4}?`)
 	reIsOffsetWithColon = regexp.MustCompile(`[+-]?\d{1,2}:\d{2}`)
+
+	// compiled once as GetFormat may run many times per line when scanning text
+	reAnsiGitRubyUnix        = regexp.MustCompile(regExAnsiGitRubyUnix)
+	reGitDateTime            = regexp.MustCompile(regExGitDateTime)
+	reRFC3339                = regexp.MustCompile(regExRFC3339)
+	reRFCUnitedKingdom       = regexp.MustCompile(regExRFCUnitedKingdom)
+	reSQLDateTime            = regexp.MustCompile(regExSQLDateTime)
+	reTZAbbreviation         = regexp.MustCompile(`[A-Z][A-Z]+`)
+	reTZOffsetWithColon      = regexp.MustCompile(`[+-]\d+:\d+`)
+	reUnixTimeStamp          = regexp.MustCompile(regExUnixTimeStamp)
+	reUpperWord              = regexp.MustCompile(`^ [A-Z]+$`)
+	reUSCommonDateTime       = regexp.MustCompile(regExUSCommonDateTime)
+	reUSCommonDateTimeStrict = regexp.MustCompile(regExUSCommonDateTimeStrict)
 )
 
+var (
+	// DefaultLocation is the zone assumed for inputs that do not include one,
+	// such as SQL date-times and RFC 3164 syslog timestamps. nil means UTC.
+	DefaultLocation *time.Location
+
+	// ReferenceTime is the time missing years are inferred relative to, such as
+	// for RFC 3164 syslog timestamps, and relative expressions such as "2 hours
+	// ago" count from. The zero value means now.
+	ReferenceTime time.Time
+)
+
+// defaultLocation returns DefaultLocation, or UTC when it is not set
+func defaultLocation() *time.Location {
+	if DefaultLocation != nil {
+		return DefaultLocation
+	}
+	return time.UTC
+}
+
 func Debug() {
 	debug = true
 }
@@ -189,6 +221,13 @@ func init() {
 func GetFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 	DebugPrintf("chronus.GetFormat() | dtz: %q\n", dtz)
 
+	// Check if it's an ASN.1 UTCTime or GeneralizedTime (X.509, LDAP, Kerberos)
+	// before UNIX timestamps so 20210308160634.5 is not read as a decimal timestamp
+	format = GetASN1TimeFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
 	// DebugPrintf("chronus.GetFormat() | %s\n", "UNIX TimeStamp")
 	// Check if it's a UNIX TimeStamp
 	format = GetUnixTimeStampFormat(dtz)
@@ -196,6 +235,58 @@ func GetFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 		return format, tzloc
 	}
 
+	// Check if it's an astronomical day number such as JD 2459282.17
+	format = GetDayNumberFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's a relative expression such as "2 hours ago" or "tomorrow 09:30"
+	format = GetRelativeFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's a time ordered ID such as a UUID v7 or ULID
+	format = GetIDFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's an RFC 9110 HTTP-date
+	format = GetHTTPDateFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's an RFC 5322 (email) date-time
+	format = GetRFC5322Format(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's a syslog (RFC 3164 or RFC 5424) timestamp or message
+	format = GetSyslogFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's a web server or load balancer log timestamp (CLF, ELB, IIS W3C)
+	format = GetWebLogFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
+	// Check if it's a PDF date or an EXIF date-time
+	format = GetPDFDateFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+	format = GetEXIFFormat(dtz)
+	if len(format) > 0 {
+		return format, tzloc
+	}
+
 	// DebugPrintf("chronus.GetFormat() | %s\n", "RFC 3339")
 	// Check if it's RFC 3339
 	format = GetRFC3339Format(dtz)
@@ -220,12 +311,10 @@ func GetFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 
 	// DebugPrintf("chronus.GetFormat() | %s\n", "ANSI C")
 	// Check if it's an ANSI C, Git, Ruby, Unix, etc. format
-	re := regexp.MustCompile(regExAnsiGitRubyUnix)
-	matched := re.MatchString(dtz)
+	matched := reAnsiGitRubyUnix.MatchString(dtz)
 
 	if matched {
-		re = regexp.MustCompile(regExGitDateTime)
-		matched = re.MatchString(dtz)
+		matched = reGitDateTime.MatchString(dtz)
 		if matched {
 			return GitDateTime, tzloc
 		}
@@ -233,29 +322,26 @@ func GetFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 
 	// DebugPrintf("chronus.GetFormat() | %s\n", "US Common")
 	// Check if it's at least partially a US Common format
-	re = regexp.MustCompile(regExUSCommonDateTime)
-	matched = re.MatchString(dtz)
+	matched = reUSCommonDateTime.MatchString(dtz)
 
 	if matched {
 		// Check if it is the US Common format
-		re = regexp.MustCompile(regExUSCommonDateTimeStrict)
-		matched = re.MatchString(dtz)
+		matched = reUSCommonDateTimeStrict.MatchString(dtz)
 
 		if matched == false {
 			//
 		}
 	} else {
 		i := 0
-		re = regexp.MustCompile(regExRFCUnitedKingdom)
-		matched = re.MatchString(dtz)
-		matches := re.FindStringSubmatch(dtz)
-		formatMatches := re.FindStringSubmatch(RFC5322C)
+		matched = reRFCUnitedKingdom.MatchString(dtz)
+		matches := reRFCUnitedKingdom.FindStringSubmatch(dtz)
+		formatMatches := reRFCUnitedKingdom.FindStringSubmatch(RFC5322C)
 
 		format = ""
 		for c, s := range matches {
 			if len(strings.TrimSpace(s)) > 0 {
 				if c > 0 {
-					if formatMatches[c] == " -0700" && regexp.MustCompile("^ [A-Z]+$").MatchString(s) {
+					if formatMatches[c] == " -0700" && reUpperWord.MatchString(s) {
 						format += " MST"
 					} else {
 						format += formatMatches[c]
@@ -277,9 +363,8 @@ func GetFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 // GetRFC3339Format determines the correct format for an RFC 3339 based string
 func GetRFC3339Format(dtz string) (format string) {
 	// DebugPrintf("chronus.GetRFC3339Format() | dtz: %q\n", dtz)
-	re := regexp.MustCompile(regExRFC3339)
-	if re.MatchString(dtz) {
-		matches := re.FindStringSubmatch(dtz)
+	if reRFC3339.MatchString(dtz) {
+		matches := reRFC3339.FindStringSubmatch(dtz)
 		// DebugPrintf("chronus.GetRFC3339Format() | matches: %q\n", matches)
 		// DebugPrintf("chronus.GetRFC3339Format() | submatches: %d\n", len(matches)-1)
 		// DebugPrintf("chronus.GetRFC3339Format() | matches[1]: %q (year)\n", matches[1])
@@ -303,9 +388,8 @@ func GetRFC3339Format(dtz string) (format string) {
 // GetSQLFormat determines the correct format for the provided SQL based date-time-zone string
 func GetSQLFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 	DebugPrintf("chronus.GetSQLFormat() | dtz: %q\n", dtz)
-	re := regexp.MustCompile(regExSQLDateTime)
-	if re.MatchString(dtz) {
-		matches := re.FindStringSubmatch(dtz)
+	if reSQLDateTime.MatchString(dtz) {
+		matches := reSQLDateTime.FindStringSubmatch(dtz)
 		DebugPrintf("chronus.GetSQLFormat() | matches: %q\n", matches)
 		DebugPrintf("chronus.GetSQLFormat() | submatches: %d\n", len(matches)-1)
 		DebugPrintf("chronus.GetSQLFormat() | matches[1]: %q (date)\n", matches[1])
@@ -380,13 +464,11 @@ func GetSQLFormat(dtz string) (format string, tzloc *tzinfo.TimeZoneLocation) {
 }
 
 func GetTimeZoneFormat(tz string) (format string) {
-	match, _ := regexp.MatchString(`[A-Z][A-Z]+`, tz)
-	if match {
+	if reTZAbbreviation.MatchString(tz) {
 		return "MST"
 	}
 
-	match, _ = regexp.MatchString(`[+-]\d+:\d+`, tz)
-	if match {
+	if reTZOffsetWithColon.MatchString(tz) {
 		return "Z07:00"
 	}
 
@@ -395,14 +477,13 @@ func GetTimeZoneFormat(tz string) (format string) {
 
 // GetUnixTimeStampFormat determines the correct format for the provided UNIX timestamp string
 func GetUnixTimeStampFormat(dtz string) (format string) {
-	re := regexp.MustCompile(regExUnixTimeStamp)
-	if re.MatchString(dtz) {
-		matches := re.FindStringSubmatch(dtz)
+	if reUnixTimeStamp.MatchString(dtz) {
+		matches := reUnixTimeStamp.FindStringSubmatch(dtz)
 		DebugPrintf("chronus.GetUnixTimeStampFormat() | UNIX timestamp matched: true | matches: %q\n", matches)
+		format = UnixTimeStamp
 		if len(matches) == 3 && len(matches[2]) > 0 {
 			format = UnixTimeStampFloat
 		}
-		format = UnixTimeStamp
 	}
 
 	return format
@@ -410,20 +491,49 @@ func GetUnixTimeStampFormat(dtz string) (format string) {
 
 // ListFormats prints a list of all supported time formats
 func ListFormats() {
-	fmt.Printf("   ISO8601alt: %q\n", ISO8601alt)
-	fmt.Printf("     ISO8601Z: %q\n", ISO8601Z)
-	fmt.Printf("      ISO8601: %q\n", ISO8601)
-	fmt.Printf("  SQLDateTime: %q\n", SQLDateTime)
-	fmt.Printf("UnixTimeStamp: %q\n", "1136239445")
+	names := FormatNames()
+	width := len("UnixTimeStamp")
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
 
+	for _, name := range names {
+		format, _ := LookupFormat(name)
+		fmt.Printf("%*s: %q\n", width, name, format)
+	}
+	fmt.Printf("%*s: %q\n", width, "UnixTimeStamp", "1136239445")
 }
 
 // Parse attempts to convert a given string into a Go time.Time
 func Parse(dtz string) (t time.Time, err error) {
+	p, err := ParseDetailed(dtz)
+	return p.Time, err
+}
+
+// Parsed describes how a date-time string was interpreted
+type Parsed struct {
+	Time   time.Time
+	Format string    // detected format or layout
+	Unit   EpochUnit // unit of a UNIX timestamp, EpochAuto for anything else
+	Locale string    // locale code when localized month or weekday names were parsed
+	IDType string    // kind of time ordered ID (e.g. UUID v7) the time was decoded from
+}
+
+// ParseDetailed converts a given string into a Go time.Time and reports the
+// detected format, UNIX timestamp unit, locale, and ID type used
+func ParseDetailed(dtz string) (p Parsed, err error) {
 	format, tzloc := GetFormat(dtz)
+	return parseDetailed(dtz, format, tzloc)
+}
+
+// parseDetailed parses dtz as the format GetFormat detected
+func parseDetailed(dtz, format string, tzloc *tzinfo.TimeZoneLocation) (p Parsed, err error) {
 	DebugPrintf("chronus.Parse() | dtz: %q\n", dtz)
 	DebugPrintf("chronus.Parse() | format: %q\n", format)
 	DebugPrintf("chronus.Parse() | tzloc: %s\n", tzloc.String())
+	p.Format = format
 
 	// loc []*tzinfo.TimeZoneLocation
 
@@ -438,30 +548,127 @@ func Parse(dtz string) (t time.Time, err error) {
 
 	switch format {
 	case UnixTimeStamp:
-		i, err := strconv.ParseInt(dtz, 10, 64)
+		var i int64
+		i, err = strconv.ParseInt(dtz, 10, 64)
 		if err == nil {
-			t = time.Unix(i, 0)
+			p.Unit = UnixTimeStampUnit
+			if p.Unit == EpochAuto {
+				p.Unit = DetectEpochUnit(i)
+			}
+			p.Time = EpochUnitToTime(i, p.Unit)
+			DebugPrintf("chronus.Parse() | epoch unit: %s\n", p.Unit)
 		}
 	case UnixTimeStampFloat:
-		f, err := strconv.ParseFloat(dtz, 64)
-		if err == nil {
-			i := int64(f * 1000000000)
-			t = time.Unix(0, i)
+		p.Unit = UnixTimeStampUnit
+		if p.Unit == EpochAuto {
+			i, _ := strconv.ParseInt(strings.SplitN(dtz, ".", 2)[0], 10, 64)
+			p.Unit = DetectEpochUnit(i)
 		}
+		p.Time, err = ParseUnixDecimalUnit(dtz, p.Unit)
+	case JulianDayFormat, ModifiedJulianDayFormat, ReducedJulianDayFormat, TruncatedJulianDayFormat, RataDieFormat:
+		p.Time, err = ParseDayNumberString(dtz)
+	case UUIDv1Format, UUIDv6Format, UUIDv7Format, ULIDFormat, KSUIDFormat, ObjectIDFormat:
+		p.Time, p.IDType, err = ParseID(dtz)
+	case IMFFixdate, RFC850Date, AsctimeDate:
+		p.Time, err = ParseHTTPDate(dtz)
+	case RFC5322DateTime:
+		p.Time, err = ParseRFC5322(dtz)
+	case RelativeFormat:
+		p.Time, err = ParseRelative(dtz)
+	case SyslogRFC3164, SyslogRFC5424:
+		p.Time, _, err = ParseSyslog(dtz)
+	case CommonLogFormat, CommonLogTime, ELBTimestamp, W3CDateTime:
+		p.Time, err = ParseWebLog(dtz)
+	case UTCTimeFormat, GeneralizedTimeFormat:
+		p.Time, err = ParseASN1Time(dtz)
+	case PDFDate:
+		p.Time, err = ParsePDFDate(dtz)
+	case EXIFDateTime:
+		p.Time, err = ParseEXIF(dtz)
 	default:
 		if tzloc != nil {
-			t, err = time.ParseInLocation(format, dtz, tzloc.Location())
+			p.Time, err = time.ParseInLocation(format, dtz, tzloc.Location())
 		} else {
-			t, err = time.Parse(format, dtz)
+			p.Time, err = time.ParseInLocation(format, dtz, defaultLocation())
 		}
 
 		if err != nil {
-			fmt.Printf("Time Parse Error: %s\n", err.Error())
+			// Fall back to the localized month and weekday names of the registered locales
+			if mayBeLocalized(dtz) {
+				if lt, code, lerr := ParseLocalized(dtz); lerr == nil {
+					DebugPrintf("chronus.Parse() | locale: %q\n", code)
+					p.Time, p.Locale, p.Format = lt, code, "Localized ("+code+")"
+					return p, nil
+				}
+			}
+			// Fall back to the lenient cookie date algorithm browsers use
+			if ct, cerr := ParseCookieDate(dtz); cerr == nil {
+				p.Time, p.Format = ct, CookieDate
+				return p, nil
+			}
+			if reUUID.MatchString(strings.TrimSpace(dtz)) {
+				// a UUID version without a timestamp such as v4
+				_, _, err = ParseID(dtz)
+			}
+			DebugPrintf("chronus.Parse() | error: %s\n", err.Error())
 			DebugPrintf("input format: %q\n", format)
 		}
 	}
 
-	return t, err
+	return p, err
+}
+
+// ParseWithFormat parses dtz using an explicit strftime format, LDML pattern
+// (Java, ICU, moment.js), or Go layout
+func ParseWithFormat(dtz, format string) (t time.Time, err error) {
+	DebugPrintf("chronus.ParseWithFormat() | dtz: %q | format: %q\n", dtz, format)
+	if named, ok := LookupFormat(format); ok {
+		format = named
+	}
+
+	switch {
+	case IsTemplate(format):
+		return t, fmt.Errorf("templates can not be used for parsing")
+	case IsStrftime(format):
+		return Strptime(dtz, format)
+	case IsMoment(format):
+		pattern, err := MomentToLDML(format)
+		if err != nil {
+			return t, err
+		}
+		return ParseLDML(dtz, pattern)
+	case IsLDML(format):
+		return ParseLDML(dtz, format)
+	}
+	return time.ParseInLocation(format, dtz, defaultLocation())
+}
+
+// Format formats t using a named format (see RegisterFormat), text/template,
+// strftime format, LDML pattern (Java, ICU, moment.js), or Go layout
+func Format(t time.Time, format string) (string, error) {
+	if named, ok := LookupFormat(format); ok {
+		format = named
+	}
+	if format == ELBTimestamp {
+		// the layout ends with a literal Z so the time must already be UTC
+		t = t.UTC()
+	}
+
+	switch {
+	case IsTemplate(format):
+		return ExecuteTemplate(t, format)
+	case IsStrftime(format):
+		return Strftime(t, format), nil
+	case IsMoment(format):
+		pattern, err := MomentToLDML(format)
+		if err != nil {
+			return "", err
+		}
+		return FormatLDML(t, pattern)
+	case IsLDML(format):
+		return FormatLDML(t, format)
+	}
+	return t.Format(format), nil
 }
 
 // UnixFloat converts Go time.Time into a floating point UNIX timestamp (ala Python) since the UNIX Epoch
@@ -480,12 +687,10 @@ func TimezoneIsOffset(tz string) int {
 	return 0
 }
 
-// UnixFloat converts Go time.Time into a floating point UNIX timestamp (ala Python) since the UNIX Epoch
+// UnixFloat converts Go time.Time into a floating point UNIX timestamp (ala Python) since the UNIX Epoch.
+// The result is the float64 nearest to the exact value; use UnixDecimalString to keep every digit.
 func UnixFloat(t time.Time) float64 {
-	ts := fmt.Sprintf("%d", t.Unix())
-	tsn := fmt.Sprintf("%d", t.UnixNano())
-	fString := fmt.Sprintf("%s.%s", ts, tsn[len(ts):])
-	fFloat, _ := strconv.ParseFloat(fString, 64)
+	fFloat, _ := strconv.ParseFloat(UnixDecimalString(t, 9), 64)
 	return fFloat
 	// return float64(t.UnixNano()) // 1_000_000_000.0
 }
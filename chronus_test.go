@@ -0,0 +1,64 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDefaultLocation(t *testing.T) {
+	defer func(loc *time.Location) { DefaultLocation = loc }(DefaultLocation)
+	DefaultLocation = time.FixedZone("MST", -7*3600)
+
+	tests := []struct {
+		dtz  string
+		want string
+	}{
+		{"2021-03-08 16:06:34", "2021-03-08T16:06:34-07:00"},
+		{"2021-03-08T16:06:34Z", "2021-03-08T16:06:34Z"},
+		{"2021-03-08T16:06:34+01:00", "2021-03-08T16:06:34+01:00"},
+		{"08.03.2021 16:06", "2021-03-08T16:06:00-07:00"},
+	}
+	for _, tt := range tests {
+		p, err := ParseDetailed(tt.dtz)
+		if err != nil {
+			t.Errorf("ParseDetailed(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if got := p.Time.Format(time.RFC3339); got != tt.want {
+			t.Errorf("ParseDetailed(%q) = %s, want %s", tt.dtz, got, tt.want)
+		}
+	}
+
+	for _, format := range []string{"%Y-%m-%d %H:%M", "yyyy-MM-dd HH:mm", "2006-01-02 15:04"} {
+		got, err := ParseWithFormat("2021-03-08 16:06", format)
+		if err != nil || got.Format(time.RFC3339) != "2021-03-08T16:06:00-07:00" {
+			t.Errorf("ParseWithFormat(%q) = %s, %v, want 2021-03-08T16:06:00-07:00", format, got, err)
+		}
+	}
+}
+
+func TestDefaultLocationNilIsUTC(t *testing.T) {
+	defer func(loc *time.Location, ref time.Time) { DefaultLocation, ReferenceTime = loc, ref }(DefaultLocation, ReferenceTime)
+	ReferenceTime = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	mst := time.FixedZone("MST", -7*3600)
+
+	parsers := map[string]func() (time.Time, error){
+		"SQL date-time": func() (time.Time, error) { return Parse("2021-03-08 16:06:34") },
+		"RFC 3164": func() (time.Time, error) {
+			t, _, err := ParseSyslog("Mar  8 16:06:34")
+			return t, err
+		},
+		"strftime":           func() (time.Time, error) { return Strptime("2021-03-08 16:06:34", "%F %T") },
+		"spreadsheet serial": func() (time.Time, error) { return ParseSpreadsheetSerial("44263.6712268518", Excel1900) },
+	}
+	for _, loc := range []*time.Location{nil, mst} {
+		DefaultLocation = loc
+		want := time.Date(2021, 3, 8, 16, 6, 34, 0, defaultLocation())
+		for name, parse := range parsers {
+			got, err := parse()
+			if err != nil || got.Sub(want) > time.Millisecond || want.Sub(got) > time.Millisecond || got.Location() != want.Location() {
+				t.Errorf("%s with DefaultLocation %v = %s, %v, want %s", name, loc, got, err, want)
+			}
+		}
+	}
+}
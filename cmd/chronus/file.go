@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+// fileColumns are the keys of each record of outputFileTimes
+var fileColumns = []string{"file", "source", "field", "raw", "rfc3339", "unix_timestamp"}
+
+// outputFileTimes prints each timestamp embedded in the file at path with the field it came from
+func outputFileTimes(path string) {
+	times, err := chronus.ReadEmbeddedTimes(path)
+	if err != nil {
+		stdError("File Error: %s\n", err.Error())
+		return
+	}
+
+	if isMachineOutput(*outputPtr) {
+		for _, et := range times {
+			record = newOutputRecord()
+			record.add("file", path, false)
+			record.add("source", et.Source, false)
+			record.add("field", et.Field, false)
+			record.add("raw", et.Raw, false)
+			record.add("rfc3339", et.Time.Format(time.RFC3339Nano), false)
+			record.add("unix_timestamp", strconv.FormatInt(et.Time.Unix(), 10), true)
+			flushRecord(*outputPtr)
+		}
+		record = nil
+		return
+	}
+
+	fmt.Printf("%29s: %q\n", "File", path)
+	if len(times) == 0 {
+		fmt.Printf("%29s: %s\n", "Embedded Times", "none found")
+	}
+	for _, et := range times {
+		fmt.Printf("%29s: %s\n", et.Source+" "+et.Field, et.Time.Format(time.RFC3339Nano))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+const idUsage = `%s
+
+Generate time ordered IDs for each DATE_TIME (or now). A DATE_TIME may also be
+an existing ID, including an undashed UUID. With -min and -max the IDs bound
+every ID created within the millisecond (or second for KSUID and ObjectID) so
+they can be used for database range scans.
+
+Usage: %s id [OPTIONS] [DATE_TIME]
+
+OPTIONS:
+`
+
+// idCommand generates ULID, UUID v7, KSUID, ObjectID, and Snowflake IDs and returns the exit code
+func idCommand(args []string) int {
+	fs := flag.NewFlagSet("id", flag.ExitOnError)
+	idTypePtr := fs.String("type", "", "ID type to generate: "+strings.Join(chronus.IDTypes, ", ")+" (all with labels when not set)")
+	maxPtr := fs.Bool("max", false, "Generate the largest ID for the time")
+	minPtr := fs.Bool("min", false, "Generate the smallest ID for the time")
+	snowflakePtr := fs.String("snowflake-epoch", "", "Epoch for Snowflake IDs: twitter, discord, milliseconds, or a date-time")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), idUsage, appLabel, filepath.Base(os.Args[0]))
+		printOptions(fs)
+	}
+	fs.Parse(args)
+
+	bound := chronus.IDRandom
+	switch {
+	case *minPtr && *maxPtr:
+		stdError("Only one of -min or -max may be used\n")
+		return 1
+	case *minPtr:
+		bound = chronus.IDMin
+	case *maxPtr:
+		bound = chronus.IDMax
+	}
+
+	if len(*snowflakePtr) > 0 {
+		epoch, err := chronus.ParseSnowflakeEpoch(*snowflakePtr)
+		if err != nil {
+			stdError("%s\n", err.Error())
+			return 1
+		}
+		chronus.SnowflakeEpoch = epoch
+	}
+
+	idTypes := chronus.IDTypes
+	if len(*idTypePtr) > 0 {
+		idTypes = []string{*idTypePtr}
+	}
+
+	inputs := fs.Args()
+	if len(inputs) == 0 {
+		inputs = []string{time.Now().Format(time.RFC3339Nano)}
+	}
+
+	exitCode := 0
+	for _, input := range inputs {
+		t, err := chronus.Parse(input)
+		if ut, uerr := chronus.UUIDToTime(input); err != nil && uerr == nil {
+			// undashed UUIDs look like any other hex digest so they are only accepted here
+			t, err = ut, nil
+		}
+		if err != nil {
+			stdError("Time Parse Error: %s\n", err.Error())
+			exitCode = 1
+			continue
+		}
+		for _, idType := range idTypes {
+			id, format, err := chronus.GenerateID(idType, t, bound)
+			if err != nil {
+				stdError("ID Error: %s\n", err.Error())
+				exitCode = 1
+				continue
+			}
+			if len(*idTypePtr) > 0 {
+				fmt.Println(id)
+			} else {
+				fmt.Printf("%29s: %s\n", format, id)
+			}
+		}
+		if len(*idTypePtr) == 0 {
+			fmt.Println()
+		}
+	}
+
+	return exitCode
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/runeimp/chronus"
@@ -15,25 +17,54 @@ const (
 	appName    = "Chronus"
 	appVersion = "0.1.0"
 	appLabel   = "Chronus v0.1.0"
+
+	// timeScaleLayout displays TAI and GPS readings which have no time zone
+	timeScaleLayout = "2006-01-02T15:04:05.999999999"
 )
 
 const usage = `%s
 
-Usage: %s [OPTIONS] [DATE_TIME]
+Usage: %[2]s [OPTIONS] [DATE_TIME]
+       %[2]s -from-file [OPTIONS] FILE...
+       %[2]s id [OPTIONS] [DATE_TIME]
+       %[2]s normalize [OPTIONS] < LOG
+       %[2]s scan [OPTIONS] [FILE...]
+       %[2]s stat [OPTIONS] PATH...
+       %[2]s touch [OPTIONS] PATH...
 
 OPTIONS:
 `
 
 var (
+	formats        formatList
+	asn1Ptr        *bool
+	clfPtr         *bool
 	countryCodePtr *string
+	dayNumbersPtr  *bool
 	debugPtr       *bool
+	defaultZonePtr *string
+	epochPtr       *string
+	epochUnitPtr   *string
+	epochsPtr      *bool
+	fromFilePtr    *bool
+	gpsPtr         *bool
 	helpPtr        *bool
+	httpPtr        *bool
+	inputFormatPtr *string
 	inputPtr       *bool
 	iso8601Ptr     *bool
 	labelPtr       *bool
+	leapSecondsPtr *string
 	listPtr        *bool
+	localePtr      *string
+	outputPtr      *string
 	pythonPtr      *bool
+	referencePtr   *string
+	refFilePtr     *string
 	rfc3339Ptr     *bool
+	snowflakePtr   *string
+	serialPtr      *string
+	serialsPtr     *bool
 	sqlDateTimePtr *bool
 	sqlPtr         *bool
 	unixAllPtr     *bool
@@ -43,15 +74,50 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "id":
+			os.Exit(idCommand(os.Args[2:]))
+		case "normalize":
+			os.Exit(normalizeCommand(os.Args[2:]))
+		case "scan":
+			os.Exit(scanCommand(os.Args[2:]))
+		case "stat":
+			os.Exit(statCommand(os.Args[2:]))
+		case "touch":
+			os.Exit(touchCommand(os.Args[2:]))
+		}
+	}
+
+	asn1Ptr = flag.Bool("asn1", false, "Display ASN.1 UTCTime and GeneralizedTime (X.509, LDAP, Kerberos)")
+	clfPtr = flag.Bool("clf", false, "Display a bracketed Common Log Format timestamp (Apache/Nginx access logs)")
 	countryCodePtr = flag.String("country-code", "", "What country code should be used in calculations")
+	dayNumbersPtr = flag.Bool("day-numbers", false, "Display astronomical day numbers (Julian Day, MJD, RJD, TJD, Rata Die)")
 	debugPtr = flag.Bool("debug", false, "Display debugging info")
+	defaultZonePtr = flag.String("default-zone", "", "Zone for inputs without one such as SQL date-times, RFC 3164 syslog timestamps, and spreadsheet serials (default UTC)")
+	epochPtr = flag.String("epoch", "", "Interpret input as a timestamp in the named epoch: "+strings.Join(chronus.EpochNames(), ", "))
+	epochUnitPtr = flag.String("epoch-unit", "auto", "Unit of integer UNIX timestamps: auto, s, ms, us, or ns")
+	flag.Var(&formats, "format", "Display time using a named format (see -list), Go layout, strftime format (e.g. '%Y-%m-%d %H:%M:%S %z'), LDML pattern (e.g. 'yyyy-MM-dd HH:mm:ss'), or text/template (e.g. '{{.Unix}} {{.ISOWeekString}} {{.InZone \"Asia/Tokyo\"}}'); may be repeated")
+	epochsPtr = flag.Bool("epochs", false, "Display the time in alternative epoch timestamps (FILETIME, .NET ticks, Cocoa, WebKit, ...)")
+	fromFilePtr = flag.Bool("from-file", false, "Treat each argument as a file and display the timestamps embedded in it (EXIF, PNG, PDF, ZIP, tar)")
+	gpsPtr = flag.Bool("gps", false, "Display TAI and GPS time including the GPS week and time of week")
 	helpPtr = flag.Bool("help", false, "Display this help info")
+	httpPtr = flag.Bool("http", false, "Display an HTTP-date (IMF-fixdate in GMT)")
 	inputPtr = flag.Bool("input", false, "Display the input referenced")
+	inputFormatPtr = flag.String("input-format", "", "Parse input using a strftime format, LDML pattern, or Go layout instead of detecting it")
 	iso8601Ptr = flag.Bool("iso8601", false, "Display time in ISO 8601 formats")
 	labelPtr = flag.Bool("label", false, "Display label for single formats")
+	leapSecondsPtr = flag.String("leap-seconds", chronus.LeapSecondsFile, "A leap-seconds.list file to use in place of the built in leap second table")
 	listPtr = flag.Bool("list", false, "List all supported formats")
+	localePtr = flag.String("locale", "", "Locale for parsing and displaying month and weekday names (en, fr, de, es, it, pt, nl, ja)")
+	outputPtr = flag.String("output", outputText, "Output style: text, json, csv, tsv, or yaml")
 	pythonPtr = flag.Bool("python", false, "Display a Python timestamp")
+	referencePtr = flag.String("reference-time", "", "Date-time relative expressions and inferred years (RFC 3164 syslog) are based on (default now)")
+	refFilePtr = flag.String("ref-file", "", "Use the modification time of a file as the input time")
 	rfc3339Ptr = flag.Bool("rfc3339", false, "Display time in RFC 3339 formats")
+	serialPtr = flag.String("serial", "", "Interpret input as a spreadsheet serial date: excel, excel1904, libreoffice, or sheets")
+	serialsPtr = flag.Bool("serials", false, "Display spreadsheet serial dates (Excel 1900 and 1904, LibreOffice, Google Sheets)")
+	snowflakePtr = flag.String("snowflake-epoch", "", "Epoch for Snowflake IDs (-epoch snowflake): twitter, discord, milliseconds, or a date-time")
 	sqlPtr = flag.Bool("sql", false, "Display SQL Date Time Formats")
 	sqlDateTimePtr = flag.Bool("sql-datetime", false, "Display a SQL DateTime")
 	unixAllPtr = flag.Bool("unix-all", false, "Display time in UNIX formats")
@@ -61,16 +127,7 @@ func main() {
 
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), usage, appLabel, filepath.Base(os.Args[0]))
-
-		flag.VisitAll(func(f *flag.Flag) {
-			optionName := fmt.Sprintf("-%s", f.Name)
-			if f.DefValue == "" {
-				fmt.Fprintf(flag.CommandLine.Output(), "  %-13s  %s (no default)\n", optionName, f.Usage)
-			} else {
-				fmt.Fprintf(flag.CommandLine.Output(), "  %-13s  %s (default: %v)\n", optionName, f.Usage, f.DefValue)
-			}
-		})
-		fmt.Println()
+		printOptions(flag.CommandLine)
 	}
 
 	flag.Parse()
@@ -93,13 +150,108 @@ func main() {
 		chronus.Debug()
 	}
 
+	unit, err := chronus.ParseEpochUnit(*epochUnitPtr)
+	if err != nil {
+		stdError("%s\n", err.Error())
+		usageAndExit(1)
+	}
+	chronus.UnixTimeStampUnit = unit
+
+	if len(*leapSecondsPtr) > 0 {
+		if err = chronus.LoadLeapSecondsFile(*leapSecondsPtr); err != nil {
+			stdError("Leap Seconds Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if len(*snowflakePtr) > 0 {
+		if chronus.SnowflakeEpoch, err = chronus.ParseSnowflakeEpoch(*snowflakePtr); err != nil {
+			stdError("%s\n", err.Error())
+			usageAndExit(1)
+		}
+	}
+
+	if len(*defaultZonePtr) > 0 {
+		if chronus.DefaultLocation, err = time.LoadLocation(*defaultZonePtr); err != nil {
+			stdError("%s\n", err.Error())
+			usageAndExit(1)
+		}
+	}
+
+	if len(*referencePtr) > 0 {
+		if chronus.ReferenceTime, err = chronus.Parse(*referencePtr); err != nil {
+			stdError("Reference Time Error: %s\n", err.Error())
+			usageAndExit(1)
+		}
+	}
+
+	if len(*epochPtr) > 0 {
+		if _, err = chronus.GetEpoch(*epochPtr); err != nil {
+			stdError("%s\n", err.Error())
+			usageAndExit(1)
+		}
+	}
+
+	if len(*serialPtr) > 0 {
+		if _, err = chronus.ParseSpreadsheetSystem(*serialPtr); err != nil {
+			stdError("%s\n", err.Error())
+			usageAndExit(1)
+		}
+	}
+
+	switch *outputPtr {
+	case outputText, outputJSON, outputCSV, outputTSV, outputYAML:
+	default:
+		stdError("Unknown output style %q\n", *outputPtr)
+		usageAndExit(1)
+	}
+
+	if len(*refFilePtr) > 0 && len(flag.Args()) > 0 {
+		stdError("-ref-file can not be combined with DATE_TIME arguments\n")
+		usageAndExit(1)
+	}
+	if *fromFilePtr && len(flag.Args()) == 0 {
+		stdError("-from-file needs FILE arguments\n")
+		usageAndExit(1)
+	}
+
+	if len(*countryCodePtr) > 0 {
+		chronus.CountryCode = *countryCodePtr
+	}
+	if len(*localePtr) > 0 {
+		chronus.LocaleCode = *localePtr
+	}
+	if isMachineOutput(*outputPtr) {
+		headerKeys = outputColumns()
+		if *fromFilePtr {
+			headerKeys = fileColumns
+		}
+	}
+
 	if len(flag.Args()) == 0 {
 		// usageAndExit(0)
 		t := time.Now()
+		if len(*refFilePtr) > 0 {
+			st, err := chronus.StatFile(*refFilePtr)
+			if err != nil {
+				stdError("Reference File Error: %s\n", err.Error())
+				os.Exit(1)
+			}
+			t = st.Modify
+		}
 		input := t.Format(time.RFC3339Nano)
+		if len(*epochPtr) > 0 {
+			epoch, _ := chronus.GetEpoch(*epochPtr)
+			input = epoch.Format(t)
+		} else if len(*serialPtr) > 0 {
+			system, _ := chronus.ParseSpreadsheetSystem(*serialPtr)
+			input = chronus.SpreadsheetSerialString(t, system, 8)
+		} else if len(*inputFormatPtr) > 0 {
+			input, _ = chronus.Format(t, *inputFormatPtr)
+		}
 		outputFormatBlocks(input)
 		// printFormatInt64WithLabel("UNIX Timestamp", t.Unix())
-		// printFormatFloat64WithLabel("Python Timestamp", chronus.PythonTimestamp(t))
+		// printFormatDecimalWithLabel("Python Timestamp", chronus.UnixDecimalString(t, 6))
 		// printFormatStringWithLabel(t, "SQL DateTime", chronus.SQLDateTime)
 		// printFormatStringWithLabel(t, "ISO 8601 Alternate", chronus.ISO8601alt)
 		// printFormatStringWithLabel(t, "ISO 8601 FileSafe 1 w/Seconds", chronus.ISO8601file1Seconds)
@@ -113,10 +265,26 @@ func main() {
 	// example = "Thu, 6 May 2021 12:46:12 PDT"
 
 	for _, input := range flag.Args() {
+		if *fromFilePtr {
+			outputFileTimes(input)
+			continue
+		}
 		outputFormatBlocks(input)
 	}
 }
 
+// formatList collects each -format option so several lines can be printed per input
+type formatList []string
+
+func (fl *formatList) String() string {
+	return strings.Join(*fl, ", ")
+}
+
+func (fl *formatList) Set(format string) error {
+	*fl = append(*fl, format)
+	return nil
+}
+
 // stdError sends a formatted string to stderr
 func stdError(f string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, f, args...)
@@ -124,21 +292,151 @@ func stdError(f string, args ...interface{}) {
 
 func outputFormatBlocks(input string) {
 	var (
-		err error
-		t   time.Time
+		err    error
+		format string
+		parsed chronus.Parsed
+		t      time.Time
 	)
-	if len(*countryCodePtr) > 0 {
-		chronus.CountryCode = *countryCodePtr
+	if isMachineOutput(*outputPtr) {
+		record = newOutputRecord()
+		defer flushRecord(*outputPtr)
+	}
+	if len(*epochPtr) > 0 {
+		epoch, _ := chronus.GetEpoch(*epochPtr)
+		format = epoch.Label
+		t, err = chronus.ParseEpoch(*epochPtr, input)
+	} else if len(*serialPtr) > 0 {
+		system, _ := chronus.ParseSpreadsheetSystem(*serialPtr)
+		format = system.String() + " Serial Date"
+		t, err = chronus.ParseSpreadsheetSerial(input, system)
+	} else if len(*inputFormatPtr) > 0 {
+		format = *inputFormatPtr
+		t, err = chronus.ParseWithFormat(input, *inputFormatPtr)
+	} else {
+		parsed, err = chronus.ParseDetailed(input)
+		format, t = parsed.Format, parsed.Time
+	}
+	unitString := ""
+	if parsed.Unit != chronus.EpochAuto {
+		unitString = parsed.Unit.String()
 	}
-	t, err = chronus.Parse(input)
 	zName, zOffset := t.Zone()
 	chronus.DebugPrintf("main.outputFormatBlocks() | t.Zone().name %q | .offset %d\n", zName, zOffset)
-	if err != nil {
-		fmt.Printf("Time Parse Error: %s\n", err.Error())
+	if record != nil {
+		record.add("input", input, false)
+		if err != nil {
+			// the zero time is not worth reporting in every representation
+			record.add("error", err.Error(), false)
+			return
+		}
+		record.add("format", format, false)
+		record.add("zone", zName, false)
+		record.add("offset", t.Format("-07:00"), false)
+		record.add("epoch_unit", unitString, false)
+		record.add("id_type", parsed.IDType, false)
+		record.add("error", "", false)
+	} else {
+		if err != nil {
+			fmt.Printf("Time Parse Error: %s\n", err.Error())
+		}
+		if *inputPtr {
+			fmt.Printf("                        Input: %q\n", input)
+			// fmt.Printf("                       Format: %q\n", format)
+		}
+		if parsed.Unit != chronus.EpochAuto && parsed.Unit != chronus.EpochSeconds {
+			fmt.Printf("%29s: UNIX timestamp in %s\n", "Interpreted As", unitString)
+		}
+		if len(parsed.IDType) > 0 {
+			fmt.Printf("%29s: %s timestamp\n", "Interpreted As", parsed.IDType)
+		}
+	}
+
+	outputFormats(t)
+}
+
+// outputColumns returns the keys the selected options add to a record, in
+// order, so every CSV and TSV row has the same columns whatever the input
+func outputColumns() []string {
+	saved := record
+	defer func() { record = saved }()
+
+	record = newOutputRecord()
+	record.sample = true
+	for _, key := range []string{"input", "format", "zone", "offset", "epoch_unit", "id_type", "error"} {
+		record.add(key, "", false)
+	}
+	// any time within the ASN.1 UTCTime years and the leap second table will do
+	outputFormats(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	return record.keys
+}
+
+// outputFormats prints or records each representation of t the options select
+func outputFormats(t time.Time) {
+
+	for i, format := range formats {
+		key := fmt.Sprintf("custom_format_%d", i+1)
+		label := "Custom Format"
+		if _, ok := chronus.LookupFormat(format); ok {
+			key = fieldKey(format)
+			label = format
+		}
+		s, err := chronus.Format(t, format)
+		if err != nil {
+			warn("Format Error: %s\n", err.Error())
+			if record != nil {
+				// keep the column so the rows line up
+				record.add(key, "", false)
+			}
+			continue
+		}
+		emit(key, label, s, false, *labelPtr)
+	}
+
+	if *asn1Ptr {
+		if utcTime, err := chronus.FormatUTCTime(t); err == nil {
+			emit(fieldKey("ASN.1 UTCTime"), "ASN.1 UTCTime", utcTime, false, true)
+		} else if record != nil {
+			// outside 1950-2049, keep the column so the rows line up
+			record.add(fieldKey("ASN.1 UTCTime"), "", false)
+		}
+		emit(fieldKey("ASN.1 GeneralizedTime"), "ASN.1 GeneralizedTime", chronus.FormatGeneralizedTime(t), false, true)
+		emitBlankLine()
+	}
+
+	if *clfPtr {
+		emit(fieldKey("Common Log Format"), "Common Log Format", chronus.FormatCommonLog(t), false, *labelPtr)
 	}
-	if *inputPtr {
-		fmt.Printf("                        Input: %q\n", input)
-		// fmt.Printf("                       Format: %q\n", format)
+
+	if *dayNumbersPtr {
+		for _, system := range chronus.DayNumberSystems {
+			printFormatDecimalWithLabel(system.String()+" ("+system.Abbr()+")", chronus.DayNumberString(t, system, 8))
+		}
+		emitBlankLine()
+	}
+
+	if *epochsPtr {
+		for _, epoch := range chronus.Epochs {
+			printFormatDecimalWithLabel(epoch.Label, epoch.Format(t))
+		}
+		emitBlankLine()
+	}
+
+	if *gpsPtr {
+		if chronus.LeapSecondsExpired(t) {
+			warn("Leap Seconds Warning: the leap second table expired %s, use -leap-seconds with a current leap-seconds.list\n", chronus.LeapSecondsExpire.Format("2006-01-02"))
+		}
+		week, tow := chronus.GPSWeekTOW(t)
+		printFormatInt64WithLabel("TAI-UTC Offset", int64(chronus.TAIOffset(t)))
+		emit(fieldKey("TAI DateTime"), "TAI DateTime", chronus.UTCToTAI(t).Format(timeScaleLayout)+" TAI", false, true)
+		emit(fieldKey("GPS DateTime"), "GPS DateTime", chronus.UTCToGPS(t).Format(timeScaleLayout)+" GPS", false, true)
+		printFormatInt64WithLabel("GPS Week", int64(week))
+		printFormatDecimalWithLabel("GPS Time of Week", strconv.FormatFloat(tow.Seconds(), 'f', -1, 64))
+		printFormatInt64WithLabel("GPS Seconds", chronus.GPSSeconds(t))
+		emitBlankLine()
+	}
+
+	if *httpPtr {
+		emit(fieldKey("HTTP Date"), "HTTP Date", chronus.FormatHTTPDate(t), false, *labelPtr)
 	}
 
 	if *internetPtr {
@@ -164,44 +462,39 @@ func outputFormatBlocks(input string) {
 		// printFormatStringWithLabel(t, "____", ____)
 		// printFormatStringWithLabel(t, "____", ____)
 		// printFormatStringWithLabel(t, "____", ____)
-		fmt.Println()
+		emitBlankLine()
 	}
 
 	if *pythonPtr {
-		if *labelPtr {
-			printFormatFloat64WithLabel("Python Timestamp", chronus.PythonTimestamp(t))
-		} else {
-			fmt.Printf("%f\n", chronus.PythonTimestamp(t))
-		}
+		emit(fieldKey("Python Timestamp"), "Python Timestamp", chronus.UnixDecimalString(t, 6), true, *labelPtr)
 	}
 
 	if *rfc3339Ptr {
-		if *labelPtr {
-			printFormatStringWithLabel(t, "RFC 3339 DateTime", chronus.RFC3339)
-		} else {
-			fmt.Printf("%s\n", t.Format(chronus.RFC3339))
+		emit(fieldKey("RFC 3339 DateTime"), "RFC 3339 DateTime", t.Format(chronus.RFC3339), false, *labelPtr)
+	}
+
+	if *serialsPtr {
+		for _, system := range chronus.SpreadsheetSystems {
+			printFormatDecimalWithLabel(system.String()+" Serial Date", chronus.SpreadsheetSerialString(t, system, 8))
 		}
+		emitBlankLine()
 	}
 
 	if *sqlPtr {
-		fmt.Printf("                 SQL DateTime: %s\n", t.Format(chronus.SQLDateTime))
-		fmt.Printf(" SQL DateTime Year to Seconds: %s\n", t.Format(chronus.SQLDateTimeYearToSecond))
-		fmt.Printf("  SQL DateTime Year to Minute: %s\n", t.Format(chronus.SQLDateTimeYearToMinute))
-		fmt.Printf("         SQL Date Year to Day: %s\n", t.Format(chronus.SQLDateYearToDay))
-		fmt.Printf("       SQL Date Year to Month: %s\n", t.Format(chronus.SQLDateYearToMonth))
+		printFormatStringWithLabel(t, "SQL DateTime", chronus.SQLDateTime)
+		printFormatStringWithLabel(t, "SQL DateTime Year to Seconds", chronus.SQLDateTimeYearToSecond)
+		printFormatStringWithLabel(t, "SQL DateTime Year to Minute", chronus.SQLDateTimeYearToMinute)
+		printFormatStringWithLabel(t, "SQL Date Year to Day", chronus.SQLDateYearToDay)
+		printFormatStringWithLabel(t, "SQL Date Year to Month", chronus.SQLDateYearToMonth)
 	}
 
 	if *sqlDateTimePtr {
-		if *labelPtr {
-			fmt.Printf("                 SQL DateTime: %s\n", t.Format(chronus.SQLDateTime))
-		} else {
-			fmt.Println(t.Format(chronus.SQLDateTime))
-		}
+		emit(fieldKey("SQL DateTime"), "SQL DateTime", t.Format(chronus.SQLDateTime), false, *labelPtr)
 	}
 
 	if *unixAllPtr {
 		printFormatInt64WithLabel("UNIX Timestamp", chronus.UnixTimestamp(t))
-		printFormatFloat64WithLabel("UNIX Floating Point Timestamp", chronus.UnixFloat(t))
+		printFormatDecimalWithLabel("UNIX Floating Point Timestamp", chronus.UnixDecimalString(t, 9))
 		printFormatInt64WithLabel("UNIX Timestamp in Nanoseconds", chronus.UnixNano(t))
 		printFormatStringWithLabel(t, "ANSI C DateTime", chronus.ANSIC)
 		printFormatStringWithLabel(t, "Git DateTime", chronus.GitDateTime)
@@ -213,17 +506,25 @@ func outputFormatBlocks(input string) {
 		printFormatStringWithLabel(t, "RFC3339 DateTime", chronus.RFC3339)
 		// printFormatStringWithLabel(t, "____", ____)
 		// printFormatStringWithLabel(t, "____", ____)
-		fmt.Println()
+		emitBlankLine()
 	}
 
 	if *unixFloatPtr {
-		fmt.Printf("UNIX Floating Point Timestamp: %f\n", chronus.UnixFloat(t))
+		printFormatDecimalWithLabel("UNIX Floating Point Timestamp", chronus.UnixDecimalString(t, 9))
 	}
 
 	switch {
+	case len(formats) > 0:
+	case *asn1Ptr:
+	case *clfPtr:
+	case *dayNumbersPtr:
+	case *epochsPtr:
+	case *gpsPtr:
+	case *httpPtr:
 	case *iso8601Ptr:
 	case *pythonPtr:
 	case *rfc3339Ptr:
+	case *serialsPtr:
 	case *sqlPtr:
 	case *sqlDateTimePtr:
 	case *unixAllPtr:
@@ -231,7 +532,7 @@ func outputFormatBlocks(input string) {
 	case *internetPtr:
 	default:
 		printFormatInt64WithLabel("UNIX Timestamp", t.Unix())
-		printFormatFloat64WithLabel("Python Timestamp", chronus.PythonTimestamp(t))
+		printFormatDecimalWithLabel("Python Timestamp", chronus.UnixDecimalString(t, 6))
 		printFormatStringWithLabel(t, "SQL DateTime", chronus.SQLDateTime)
 		printFormatStringWithLabel(t, "UK Slash Date (DD/MM/YYYY)", chronus.UKSlashDate)
 		printFormatStringWithLabel(t, "US Slash Date (MM/DD/YYYY)", chronus.USSlashDate)
@@ -240,23 +541,57 @@ func outputFormatBlocks(input string) {
 		printFormatStringWithLabel(t, "ISO 8601 Alternate", chronus.ISO8601alt)
 		printFormatStringWithLabel(t, "ISO 8601 FileSafe 1 w/Seconds", chronus.ISO8601file1Seconds)
 		printFormatStringWithLabel(t, "ISO 8601 FileSafe 2 w/Seconds", chronus.ISO8601file2Seconds)
-		fmt.Println()
+		if len(chronus.LocaleCode) > 0 {
+			printFormatLocaleWithLabel(t, chronus.LocaleCode)
+		}
+		emitBlankLine()
 	}
 }
 
-func printFormatFloat64WithLabel(label string, f float64) {
-	fmt.Printf("%29s: %f\n", label, f)
+// printFormatDecimalWithLabel prints an exact decimal string such as chronus.UnixDecimalString returns
+func printFormatDecimalWithLabel(label, decimal string) {
+	emit(fieldKey(label), label, decimal, true, true)
 }
 
 func printFormatInt64WithLabel(label string, d int64) {
-	fmt.Printf("%29s: %d\n", label, d)
+	emit(fieldKey(label), label, fmt.Sprintf("%d", d), true, true)
+}
+
+func printFormatLocaleWithLabel(t time.Time, code string) {
+	locale, err := chronus.GetLocale(code)
+	if err != nil {
+		warn("Locale Error: %s\n", err.Error())
+		if record != nil {
+			record.add("localized_datetime", "", false)
+		}
+		return
+	}
+	label := fmt.Sprintf("Localized DateTime (%s)", locale.Code)
+	emit("localized_datetime", label, locale.Format(t, locale.DateTimeLayout), false, true)
 }
 
 func printFormatStringWithLabel(t time.Time, label, format string) {
-	fmt.Printf("%29s: %s\n", label, t.Format(format))
+	emit(fieldKey(label), label, t.Format(format), false, true)
 	// fmt.Printf("%29s: %s | format: %q\n", label, t.Format(format), format)
 }
 
+// printOptions lists the options of a flag set with their defaults
+func printOptions(fs *flag.FlagSet) {
+	fs.VisitAll(func(f *flag.Flag) {
+		optionName := fmt.Sprintf("-%s", f.Name)
+		switch {
+		case f.DefValue == "" && strings.Contains(f.Usage, "(default "):
+			// the usage describes what an empty value means
+			fmt.Fprintf(fs.Output(), "  %-13s  %s\n", optionName, f.Usage)
+		case f.DefValue == "":
+			fmt.Fprintf(fs.Output(), "  %-13s  %s (no default)\n", optionName, f.Usage)
+		default:
+			fmt.Fprintf(fs.Output(), "  %-13s  %s (default: %v)\n", optionName, f.Usage, f.DefValue)
+		}
+	})
+	fmt.Println()
+}
+
 func usageAndExit(exitCode int) {
 	flag.Usage()
 	os.Exit(exitCode)
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+const normalizeUsage = `%s
+
+Read lines from stdin and rewrite the timestamp at the start of each line (or
+the one selected with -regex) into one format and zone, passing the rest of the
+line through. Lines without a timestamp are passed through unchanged.
+
+Usage: %s normalize [OPTIONS] < LOG
+
+OPTIONS:
+`
+
+// normalizeCommand rewrites the timestamps of the lines on stdin and returns the exit code
+func normalizeCommand(args []string) int {
+	fs := flag.NewFlagSet("normalize", flag.ExitOnError)
+	countryCodePtr := fs.String("country-code", "", "Country code for resolving zone abbreviations such as CST")
+	defaultZonePtr := fs.String("default-zone", "", "Zone for timestamps without one such as SQL date-times and RFC 3164 syslog (default UTC)")
+	formatPtr := fs.String("format", time.RFC3339Nano, "Named format, strftime format, LDML pattern, or Go layout to write")
+	inputFormatPtr := fs.String("input-format", "", "Parse the -regex selected timestamp with a strftime format, LDML pattern, or Go layout")
+	referencePtr := fs.String("reference-time", "", "Date-time inferred years (RFC 3164 syslog) are based on (default now)")
+	regexPtr := fs.String("regex", "", "Regular expression selecting the timestamp (its first group when it has one)")
+	zonePtr := fs.String("zone", "", "Zone to convert to such as UTC, Local, or America/Denver (default the timestamp's own)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), normalizeUsage, appLabel, filepath.Base(os.Args[0]))
+		printOptions(fs)
+	}
+	fs.Parse(args)
+
+	var (
+		err error
+		loc *time.Location
+		re  *regexp.Regexp
+	)
+	if len(*regexPtr) > 0 {
+		if re, err = regexp.Compile(*regexPtr); err != nil {
+			stdError("Regex Error: %s\n", err.Error())
+			return 1
+		}
+	} else if len(*inputFormatPtr) > 0 {
+		stdError("-input-format requires -regex\n")
+		return 1
+	}
+	if len(*zonePtr) > 0 {
+		if loc, err = time.LoadLocation(*zonePtr); err != nil {
+			stdError("%s\n", err.Error())
+			return 1
+		}
+	}
+	if !setParseOptions(*countryCodePtr, *defaultZonePtr, *referencePtr) {
+		return 1
+	}
+	if _, err = chronus.Format(time.Now(), *formatPtr); err != nil {
+		stdError("Format Error: %s\n", err.Error())
+		return 1
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		var (
+			t          time.Time
+			start, end int
+		)
+		if re != nil {
+			m := re.FindStringSubmatchIndex(line)
+			if m == nil {
+				fmt.Fprintln(w, line)
+				continue
+			}
+			start, end = m[0], m[1]
+			if len(m) > 2 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			if len(*inputFormatPtr) > 0 {
+				t, err = chronus.ParseWithFormat(line[start:end], *inputFormatPtr)
+			} else {
+				t, err = chronus.Parse(line[start:end])
+			}
+		} else {
+			var p chronus.Parsed
+			p, start, end, err = chronus.ParseLeading(line)
+			t = p.Time
+		}
+		if err != nil {
+			chronus.DebugPrintf("main.normalizeCommand() | %s\n", err.Error())
+			fmt.Fprintln(w, line)
+			continue
+		}
+
+		if loc != nil {
+			t = t.In(loc)
+		}
+		s, _ := chronus.Format(t, *formatPtr)
+		fmt.Fprintln(w, line[:start]+s+line[end:])
+	}
+	if err = scanner.Err(); err != nil {
+		w.Flush()
+		stdError("Read Error: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// setParseOptions sets the package options used when parsing timestamps without
+// a zone, year, or unambiguous abbreviation and reports whether they were valid
+func setParseOptions(countryCode, defaultZone, reference string) bool {
+	var err error
+	if len(defaultZone) > 0 {
+		if chronus.DefaultLocation, err = time.LoadLocation(defaultZone); err != nil {
+			stdError("%s\n", err.Error())
+			return false
+		}
+	}
+	if len(reference) > 0 {
+		if chronus.ReferenceTime, err = chronus.Parse(reference); err != nil {
+			stdError("Reference Time Error: %s\n", err.Error())
+			return false
+		}
+	}
+	if len(countryCode) > 0 {
+		chronus.CountryCode = countryCode
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputCSV  = "csv"
+	outputTSV  = "tsv"
+	outputYAML = "yaml"
+)
+
+var (
+	// record collects the fields for the current input when the output is machine readable
+	record       *outputRecord
+	reKeyInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+	// headerKeys are the CSV and TSV columns, from outputColumns or else the first record written
+	headerKeys    []string
+	headerWritten bool
+)
+
+// outputRecord holds the fields for one input in the order they were added
+type outputRecord struct {
+	keys    []string
+	values  map[string]string
+	numeric map[string]bool
+
+	// sample is set when the record only collects the column keys
+	sample bool
+}
+
+func newOutputRecord() *outputRecord {
+	return &outputRecord{
+		values:  map[string]string{},
+		numeric: map[string]bool{},
+	}
+}
+
+// add appends a field, keeping the first value when a key is repeated
+func (r *outputRecord) add(key, value string, numeric bool) {
+	if _, ok := r.values[key]; ok {
+		return
+	}
+	r.keys = append(r.keys, key)
+	r.values[key] = value
+	r.numeric[key] = numeric
+}
+
+// fieldKey turns a label such as "UK Slash Date (DD/MM/YYYY)" into a stable key like uk_slash_date_dd_mm_yyyy
+func fieldKey(label string) string {
+	return strings.Trim(reKeyInvalid.ReplaceAllString(strings.ToLower(label), "_"), "_")
+}
+
+// isMachineOutput reports whether the output option selects a machine readable format
+func isMachineOutput(output string) bool {
+	switch output {
+	case outputJSON, outputCSV, outputTSV, outputYAML:
+		return true
+	}
+	return false
+}
+
+// emit prints a labelled value, a bare value, or records it for machine readable output
+func emit(key, label, value string, numeric, labelled bool) {
+	if record != nil {
+		record.add(key, value, numeric)
+		return
+	}
+	if labelled {
+		fmt.Printf("%29s: %s\n", label, value)
+	} else {
+		fmt.Println(value)
+	}
+}
+
+// warn reports a problem on stderr unless the record only collects the column keys
+func warn(f string, args ...interface{}) {
+	if record == nil || !record.sample {
+		stdError(f, args...)
+	}
+}
+
+// emitBlankLine separates blocks in the text output
+func emitBlankLine() {
+	if record == nil {
+		fmt.Println()
+	}
+}
+
+// writeRecord writes the record in the selected machine readable format
+func writeRecord(w io.Writer, output string, r *outputRecord) {
+	switch output {
+	case outputJSON:
+		fmt.Fprint(w, "{")
+		for i, key := range r.keys {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, "%s:%s", jsonString(key), r.jsonValue(key))
+		}
+		fmt.Fprintln(w, "}")
+	case outputYAML:
+		for i, key := range r.keys {
+			prefix := "  "
+			if i == 0 {
+				prefix = "- "
+			}
+			fmt.Fprintf(w, "%s%s: %s\n", prefix, key, r.jsonValue(key))
+		}
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		if !headerWritten {
+			cw.Write(header(r))
+		}
+		cw.Write(r.row(headerKeys, nil))
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			stdError("Output Error: %s\n", err.Error())
+		}
+	case outputTSV:
+		if !headerWritten {
+			fmt.Fprintln(w, strings.Join(header(r), "\t"))
+		}
+		escaper := strings.NewReplacer("\\", `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+		fmt.Fprintln(w, strings.Join(r.row(headerKeys, escaper), "\t"))
+	}
+}
+
+// header returns the columns to write before the first row, taking them from r
+// when they were not set up front
+func header(r *outputRecord) []string {
+	if headerKeys == nil {
+		headerKeys = r.keys
+	}
+	headerWritten = true
+	return headerKeys
+}
+
+// row returns the values in the order of the header columns with an empty cell
+// for each missing key. Keys that are not in the header can not be added to the
+// table once it has started and are reported instead.
+func (r *outputRecord) row(header []string, escaper *strings.Replacer) []string {
+	row := make([]string, len(header))
+	columns := map[string]bool{}
+	for i, key := range header {
+		columns[key] = true
+		row[i] = r.values[key]
+		if escaper != nil {
+			row[i] = escaper.Replace(row[i])
+		}
+	}
+	for _, key := range r.keys {
+		if !columns[key] {
+			stdError("Output Warning: %q is not a column of the table and was left out\n", key)
+		}
+	}
+	return row
+}
+
+// jsonValue returns the value as a JSON number or string (which is also valid YAML)
+func (r *outputRecord) jsonValue(key string) string {
+	if r.numeric[key] {
+		if len(r.values[key]) == 0 {
+			return "null"
+		}
+		return r.values[key]
+	}
+	return jsonString(r.values[key])
+}
+
+func jsonString(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		stdError("Output Error: %s\n", err.Error())
+		return `""`
+	}
+	return string(b)
+}
+
+// flushRecord writes out the current record when collecting machine readable output
+func flushRecord(output string) {
+	if record != nil {
+		writeRecord(os.Stdout, output, record)
+	}
+}
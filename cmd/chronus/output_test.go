@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteRecordColumns(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{outputCSV, "input,utc_time,year\n2021,210308160634Z,2021\n2070,,2070\n"},
+		{outputTSV, "input\tutc_time\tyear\n2021\t210308160634Z\t2021\n2070\t\t2070\n"},
+	}
+	for _, tt := range tests {
+		headerKeys, headerWritten = nil, false
+		var b bytes.Buffer
+
+		r := newOutputRecord()
+		r.add("input", "2021", false)
+		r.add("utc_time", "210308160634Z", false)
+		r.add("year", "2021", true)
+		writeRecord(&b, tt.output, r)
+
+		// a later record missing a key keeps the columns of the first
+		r = newOutputRecord()
+		r.add("input", "2070", false)
+		r.add("year", "2070", true)
+		writeRecord(&b, tt.output, r)
+
+		if got := b.String(); got != tt.want {
+			t.Errorf("writeRecord(%s) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+	headerKeys, headerWritten = nil, false
+}
+
+func TestWriteRecordFixedColumns(t *testing.T) {
+	defer func() { headerKeys, headerWritten = nil, false }()
+	headerKeys, headerWritten = []string{"input", "rfc_3339_datetime", "error"}, false
+
+	// a failed input first must not narrow the table
+	var b bytes.Buffer
+	r := newOutputRecord()
+	r.add("input", "nonsense", false)
+	r.add("error", "bad input", false)
+	writeRecord(&b, outputCSV, r)
+	r = newOutputRecord()
+	r.add("input", "1615219594", false)
+	r.add("rfc_3339_datetime", "2021-03-08T16:06:34Z", false)
+	r.add("error", "", false)
+	writeRecord(&b, outputCSV, r)
+
+	want := "input,rfc_3339_datetime,error\nnonsense,,bad input\n1615219594,2021-03-08T16:06:34Z,\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeRecord() = %q, want %q", got, want)
+	}
+}
+
+func TestJSONValueEmptyNumber(t *testing.T) {
+	r := newOutputRecord()
+	r.add("gps_week", "", true)
+	r.add("zone", "", false)
+	if got := r.jsonValue("gps_week"); got != "null" {
+		t.Errorf("jsonValue(empty number) = %s, want null", got)
+	}
+	if got := r.jsonValue("zone"); got != `""` {
+		t.Errorf("jsonValue(empty string) = %s, want \"\"", got)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+const scanUsage = `%s
+
+Find the date-times anywhere in text read from each FILE (or stdin) and write
+the text back with each one annotated with its equivalent in brackets, replaced
+by it with -replace, or list them with their byte offsets with -list.
+
+Usage: %s scan [OPTIONS] [FILE...]
+
+OPTIONS:
+`
+
+// scanCommand annotates, replaces, or lists the date-times in text and returns the exit code
+func scanCommand(args []string) int {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	countryCodePtr := fs.String("country-code", "", "Country code for resolving zone abbreviations such as CST")
+	defaultZonePtr := fs.String("default-zone", "", "Zone for date-times without one such as SQL date-times and RFC 3164 syslog (default UTC)")
+	formatPtr := fs.String("format", time.RFC3339Nano, "Named format, strftime format, LDML pattern, or Go layout to write")
+	listPtr := fs.Bool("list", false, "List each date-time with its byte offsets and detected format instead of the text")
+	scanOutputPtr := fs.String("output", outputText, "Output style for -list: text, json, csv, tsv, or yaml")
+	referencePtr := fs.String("reference-time", "", "Date-time inferred years (RFC 3164 syslog) are based on (default now)")
+	replacePtr := fs.Bool("replace", false, "Replace each date-time instead of annotating it")
+	zonePtr := fs.String("zone", "UTC", "Zone to convert to such as UTC, Local, or America/Denver (empty keeps each date-time's own)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), scanUsage, appLabel, filepath.Base(os.Args[0]))
+		printOptions(fs)
+	}
+	fs.Parse(args)
+
+	switch *scanOutputPtr {
+	case outputText, outputJSON, outputCSV, outputTSV, outputYAML:
+	default:
+		stdError("Unknown output style %q\n", *scanOutputPtr)
+		return 1
+	}
+	var (
+		err error
+		loc *time.Location
+	)
+	if len(*zonePtr) > 0 {
+		if loc, err = time.LoadLocation(*zonePtr); err != nil {
+			stdError("%s\n", err.Error())
+			return 1
+		}
+	}
+	if !setParseOptions(*countryCodePtr, *defaultZonePtr, *referencePtr) {
+		return 1
+	}
+	if _, err = chronus.Format(time.Now(), *formatPtr); err != nil {
+		stdError("Format Error: %s\n", err.Error())
+		return 1
+	}
+	convert := func(t time.Time) string {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		s, _ := chronus.Format(t, *formatPtr)
+		return s
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	exitCode := 0
+	for _, path := range paths {
+		var data []byte
+		if path == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			stdError("Read Error: %s\n", err.Error())
+			exitCode = 1
+			continue
+		}
+		text := string(data)
+
+		if !*listPtr {
+			fmt.Print(chronus.ReplaceTimes(text, func(m chronus.TextMatch) string {
+				if *replacePtr {
+					return convert(m.Time)
+				}
+				return m.Text + " [" + convert(m.Time) + "]"
+			}))
+			continue
+		}
+
+		for _, m := range chronus.FindTimes(text) {
+			record = nil
+			if isMachineOutput(*scanOutputPtr) {
+				record = newOutputRecord()
+			}
+			emit("file", "File", path, false, true)
+			emit("line", "Line", strconv.Itoa(strings.Count(text[:m.Start], "\n")+1), true, true)
+			emit("start", "Start", strconv.Itoa(m.Start), true, true)
+			emit("end", "End", strconv.Itoa(m.End), true, true)
+			emit("text", "Text", m.Text, false, true)
+			emit("format", "Format", m.Format, false, true)
+			emit("time", "Time", convert(m.Time), false, true)
+			flushRecord(*scanOutputPtr)
+			emitBlankLine()
+		}
+		record = nil
+	}
+
+	return exitCode
+}
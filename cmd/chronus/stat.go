@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+const statUsage = `%s
+
+Display the access, modification, change, and birth times of each PATH with
+nanosecond precision. Times the platform or filesystem does not record are
+shown as unavailable (or empty in machine readable output).
+
+Usage: %s stat [OPTIONS] PATH...
+
+OPTIONS:
+`
+
+// statCommand prints the filesystem timestamps of each path and returns the exit code
+func statCommand(args []string) int {
+	fs := flag.NewFlagSet("stat", flag.ExitOnError)
+	formatPtr := fs.String("format", time.RFC3339Nano, "strftime format, LDML pattern, or Go layout for the times")
+	statOutputPtr := fs.String("output", outputText, "Output style: text, json, csv, tsv, or yaml")
+	utcPtr := fs.Bool("utc", false, "Display the times in UTC instead of local time")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), statUsage, appLabel, filepath.Base(os.Args[0]))
+		printOptions(fs)
+	}
+	fs.Parse(args)
+
+	switch *statOutputPtr {
+	case outputText, outputJSON, outputCSV, outputTSV, outputYAML:
+	default:
+		stdError("Unknown output style %q\n", *statOutputPtr)
+		return 1
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	exitCode := 0
+	for _, path := range fs.Args() {
+		st, err := chronus.StatFile(path)
+		if err != nil {
+			stdError("Stat Error: %s\n", err.Error())
+			exitCode = 1
+			continue
+		}
+
+		record = nil
+		if isMachineOutput(*statOutputPtr) {
+			record = newOutputRecord()
+			record.add("path", path, false)
+		} else {
+			fmt.Printf("%29s: %q\n", "Path", path)
+		}
+		if err = emitFileStat(st, *formatPtr, *utcPtr); err != nil {
+			stdError("Format Error: %s\n", err.Error())
+			return 1
+		}
+		flushRecord(*statOutputPtr)
+		emitBlankLine()
+	}
+	record = nil
+
+	return exitCode
+}
+
+// emitFileStat prints or records each time of st, showing the times the
+// platform or filesystem does not record as unavailable
+func emitFileStat(st chronus.FileStat, format string, utc bool) (err error) {
+	for _, field := range []struct {
+		label string
+		t     time.Time
+	}{
+		{"Access (atime)", st.Access},
+		{"Modify (mtime)", st.Modify},
+		{"Change (ctime)", st.Change},
+		{"Birth (btime)", st.Birth},
+	} {
+		value := ""
+		if !field.t.IsZero() {
+			t := field.t
+			if utc {
+				t = t.UTC()
+			}
+			if value, err = chronus.Format(t, format); err != nil {
+				return err
+			}
+		} else if record == nil {
+			value = "unavailable"
+		}
+		emit(fieldKey(field.label), field.label, value, false, true)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestEmitFileStat(t *testing.T) {
+	// only a modification time, as on platforms without the others
+	st := chronus.FileStat{Path: "file", Modify: time.Date(2021, 3, 8, 16, 6, 34, 0, time.FixedZone("", -7*3600))}
+
+	record = nil
+	got := captureStdout(t, func() {
+		if err := emitFileStat(st, "%Y-%m-%d %H:%M:%S", true); err != nil {
+			t.Error(err)
+		}
+	})
+	for _, line := range []string{
+		"Access (atime): unavailable",
+		"Modify (mtime): 2021-03-08 23:06:34",
+		"Change (ctime): unavailable",
+		"Birth (btime): unavailable",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("emitFileStat() = %q, want a line %q", got, line)
+		}
+	}
+
+	record = newOutputRecord()
+	defer func() { record = nil }()
+	if err := emitFileStat(st, time.RFC3339, false); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.values["access_atime"]; v != "" {
+		t.Errorf("access_atime = %q, want an empty value", v)
+	}
+	if v := record.values["modify_mtime"]; v != "2021-03-08T16:06:34-07:00" {
+		t.Errorf("modify_mtime = %q, want 2021-03-08T16:06:34-07:00", v)
+	}
+}
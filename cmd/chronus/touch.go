@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+const touchUsage = `%s
+
+Set the access and modification times of each PATH to a DATE_TIME in any form
+chronus parses, including relative expressions such as "2 hours ago" and zone
+abbreviations resolved with -country-code. Missing files are created unless -c
+is given. With -from-exif each file's own capture or creation date is used.
+
+Usage: %s touch [OPTIONS] PATH...
+
+OPTIONS:
+`
+
+// touchCommand sets file times like touch(1) and returns the exit code
+func touchCommand(args []string) int {
+	fs := flag.NewFlagSet("touch", flag.ExitOnError)
+	accessPtr := fs.Bool("a", false, "Change only the access time")
+	noCreatePtr := fs.Bool("c", false, "Do not create files that do not exist")
+	countryCodePtr := fs.String("country-code", "", "Country code for resolving zone abbreviations such as CST")
+	fromEXIFPtr := fs.Bool("from-exif", false, "Use each file's embedded capture date (EXIF DateTimeOriginal, PNG Creation Time, PDF CreationDate)")
+	modifyPtr := fs.Bool("m", false, "Change only the modification time")
+	refFilePtr := fs.String("ref-file", "", "Use the times of this file instead of the current time")
+	fs.StringVar(refFilePtr, "r", "", "Same as -ref-file")
+	datePtr := fs.String("t", "", "DATE_TIME to use instead of the current time")
+	verbosePtr := fs.Bool("v", false, "Display the time set for each file")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), touchUsage, appLabel, filepath.Base(os.Args[0]))
+		printOptions(fs)
+	}
+	fs.Parse(args)
+
+	sources := 0
+	for _, set := range []bool{len(*datePtr) > 0, len(*refFilePtr) > 0, *fromEXIFPtr} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources > 1:
+		stdError("Only one of -t, -r (-ref-file), or -from-exif may be used\n")
+		return 1
+	case fs.NArg() == 0:
+		fs.Usage()
+		return 1
+	}
+	if len(*countryCodePtr) > 0 {
+		chronus.CountryCode = *countryCodePtr
+	}
+
+	// -a and -m together are the same as neither
+	setAccess := *accessPtr || !*modifyPtr
+	setModify := *modifyPtr || !*accessPtr
+
+	atime := time.Now()
+	mtime := atime
+	switch {
+	case len(*datePtr) > 0:
+		t, err := chronus.Parse(*datePtr)
+		if err != nil {
+			stdError("Time Parse Error: %s\n", err.Error())
+			return 1
+		}
+		atime, mtime = t, t
+	case len(*refFilePtr) > 0:
+		st, err := chronus.StatFile(*refFilePtr)
+		if err != nil {
+			stdError("Reference File Error: %s\n", err.Error())
+			return 1
+		}
+		atime, mtime = st.Access, st.Modify
+		if atime.IsZero() {
+			// the platform does not record access times
+			atime = mtime
+		}
+	}
+
+	exitCode := 0
+	for _, path := range fs.Args() {
+		if *fromEXIFPtr {
+			times, err := chronus.ReadEmbeddedTimes(path)
+			if err != nil {
+				stdError("File Error: %s\n", err.Error())
+				exitCode = 1
+				continue
+			}
+			et, ok := chronus.CaptureTime(times)
+			if !ok {
+				stdError("File Error: %s: no embedded capture or creation date\n", path)
+				exitCode = 1
+				continue
+			}
+			atime, mtime = et.Time, et.Time
+		}
+
+		if err := touchFile(path, atime, mtime, setAccess, setModify, !*noCreatePtr); err != nil {
+			stdError("Touch Error: %s\n", err.Error())
+			exitCode = 1
+			continue
+		}
+		if *verbosePtr {
+			t := mtime
+			if !setModify {
+				t = atime
+			}
+			fmt.Printf("%s: %s\n", path, t.Format(time.RFC3339Nano))
+		}
+	}
+
+	return exitCode
+}
+
+// touchFile sets the selected times of path keeping the other as it was
+func touchFile(path string, atime, mtime time.Time, setAccess, setModify, create bool) error {
+	st, err := chronus.StatFile(path)
+	if os.IsNotExist(err) {
+		if !create {
+			return nil
+		}
+		f, cerr := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0666)
+		if cerr != nil {
+			return cerr
+		}
+		if cerr = f.Close(); cerr != nil {
+			return cerr
+		}
+		st, err = chronus.StatFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !setAccess {
+		atime = st.Access
+		if atime.IsZero() {
+			atime = st.Modify
+		}
+	}
+	if !setModify {
+		mtime = st.Modify
+	}
+	return os.Chtimes(path, atime, mtime)
+}
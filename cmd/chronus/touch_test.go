@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/runeimp/chronus"
+)
+
+func TestTouchCommand(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	when := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+	times := func(path string) (atime, mtime time.Time) {
+		st, err := chronus.StatFile(path)
+		if err != nil {
+			t.Fatalf("StatFile(%s): %s", path, err)
+		}
+		return st.Access, st.Modify
+	}
+	file := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// -c does not create a missing file
+	missing := filepath.Join(dir, "missing")
+	if code := touchCommand([]string{"-c", "-t", "2021-03-08T16:06:34Z", missing}); code != 0 {
+		t.Errorf("touch -c exit code = %d", code)
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("touch -c created %s", missing)
+	}
+
+	// without -c the file is created with both times set
+	created := filepath.Join(dir, "created")
+	touchCommand([]string{"-t", "2021-03-08T16:06:34Z", created})
+	if atime, mtime := times(created); !atime.Equal(when) || !mtime.Equal(when) {
+		t.Errorf("touch -t set %s, %s, want %s", atime, mtime, when)
+	}
+
+	// -m only changes the modification time
+	modified := file("modified")
+	touchCommand([]string{"-m", "-t", "2021-03-08T16:06:34Z", modified})
+	if atime, mtime := times(modified); !atime.Equal(old) || !mtime.Equal(when) {
+		t.Errorf("touch -m set %s, %s, want %s, %s", atime, mtime, old, when)
+	}
+
+	// -r (-ref-file) copies both times of another file
+	ref := file("ref")
+	os.Chtimes(ref, when, when.Add(time.Hour))
+	for _, flag := range []string{"-r", "-ref-file"} {
+		target := file("target")
+		touchCommand([]string{flag, ref, target})
+		if atime, mtime := times(target); !atime.Equal(when) || !mtime.Equal(when.Add(time.Hour)) {
+			t.Errorf("touch %s set %s, %s, want %s, %s", flag, atime, mtime, when, when.Add(time.Hour))
+		}
+	}
+
+	if code := touchCommand([]string{"-t", "now", "-r", ref, file("target")}); code != 1 {
+		t.Errorf("touch with two time sources exit code = %d, want 1", code)
+	}
+}
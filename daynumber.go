@@ -0,0 +1,196 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DayNumberSystem identifies an astronomical or calendrical day count. Day
+// numbers count days (with the time of day as the fraction) in UTC.
+type DayNumberSystem int
+
+const (
+	// JulianDay counts days from noon 4713-01-01 BC (proleptic Julian calendar)
+	JulianDay DayNumberSystem = iota
+	// ModifiedJulianDay is JD - 2400000.5 and counts from midnight 1858-11-17
+	ModifiedJulianDay
+	// ReducedJulianDay is JD - 2400000 and counts from noon 1858-11-16
+	ReducedJulianDay
+	// TruncatedJulianDay is JD - 2440000.5 (NASA) and counts from midnight 1968-05-24
+	TruncatedJulianDay
+	// RataDie counts days where 1 is 0001-01-01 (proleptic Gregorian calendar)
+	RataDie
+)
+
+const (
+	// JulianDayFormat is to denote the format is a Julian Day such as "JD 2459282.17"
+	JulianDayFormat = "Julian Day"
+
+	// ModifiedJulianDayFormat is to denote the format is a Modified Julian Date such as "MJD 59281.67"
+	ModifiedJulianDayFormat = "Modified Julian Date"
+
+	// ReducedJulianDayFormat is to denote the format is a Reduced Julian Day such as "RJD 59282.17"
+	ReducedJulianDayFormat = "Reduced Julian Day"
+
+	// TruncatedJulianDayFormat is to denote the format is a Truncated Julian Day such as "TJD 19281.67"
+	TruncatedJulianDayFormat = "Truncated Julian Day"
+
+	// RataDieFormat is to denote the format is a Rata Die day number such as "RD 737857.67"
+	RataDieFormat = "Rata Die"
+
+	regExDayNumber = `^(?i)(JD|MJD|RJD|TJD|RD)\s*:?\s*([+-]?\d+(\.\d+)?)$` // JD 2459282.17 or MJD 59281.67
+)
+
+var (
+	// DayNumberSystems lists the supported day numbers in display order
+	DayNumberSystems = []DayNumberSystem{JulianDay, ModifiedJulianDay, ReducedJulianDay, TruncatedJulianDay, RataDie}
+
+	reDayNumber = regexp.MustCompile(regExDayNumber)
+)
+
+func (d DayNumberSystem) String() string {
+	switch d {
+	case JulianDay:
+		return JulianDayFormat
+	case ModifiedJulianDay:
+		return ModifiedJulianDayFormat
+	case ReducedJulianDay:
+		return ReducedJulianDayFormat
+	case TruncatedJulianDay:
+		return TruncatedJulianDayFormat
+	case RataDie:
+		return RataDieFormat
+	}
+	return fmt.Sprintf("DayNumberSystem(%d)", int(d))
+}
+
+// Abbr returns the abbreviation used as a prefix such as JD or MJD
+func (d DayNumberSystem) Abbr() string {
+	switch d {
+	case JulianDay:
+		return "JD"
+	case ModifiedJulianDay:
+		return "MJD"
+	case ReducedJulianDay:
+		return "RJD"
+	case TruncatedJulianDay:
+		return "TJD"
+	case RataDie:
+		return "RD"
+	}
+	return ""
+}
+
+// epoch returns the UNIX timestamp of day number 0
+func (d DayNumberSystem) epoch() int64 {
+	switch d {
+	case ModifiedJulianDay:
+		return -3506716800
+	case ReducedJulianDay:
+		return -3506760000
+	case TruncatedJulianDay:
+		return -50716800
+	case RataDie:
+		return -62135683200
+	}
+	return -210866760000
+}
+
+// ParseDayNumberSystem converts an abbreviation (JD, MJD, RJD, TJD, RD) or name into a DayNumberSystem
+func ParseDayNumberSystem(name string) (DayNumberSystem, error) {
+	for _, d := range DayNumberSystems {
+		if strings.EqualFold(name, d.Abbr()) || strings.EqualFold(name, d.String()) {
+			return d, nil
+		}
+	}
+	return JulianDay, fmt.Errorf("unknown day number system %q (expected JD, MJD, RJD, TJD, or RD)", name)
+}
+
+// DayNumber converts t into a fractional day number
+func DayNumber(t time.Time, system DayNumberSystem) float64 {
+	f, _ := strconv.ParseFloat(DayNumberString(t, system, 10), 64)
+	return f
+}
+
+// DayNumberString returns the exact day number for t with precision fractional digits (truncated)
+func DayNumberString(t time.Time, system DayNumberSystem, precision int) string {
+	sec := t.Unix() - system.epoch()
+	days, rem := sec/86400, sec%86400
+	if rem < 0 {
+		days, rem = days-1, rem+86400
+	}
+	return dayDecimalString(days, rem*int64(time.Second)+int64(t.Nanosecond()), precision)
+}
+
+// DayNumberToTime converts a fractional day number into a UTC time
+func DayNumberToTime(f float64, system DayNumberSystem) time.Time {
+	t, _ := ParseDayNumber(strconv.FormatFloat(f, 'f', 10, 64), system)
+	return t
+}
+
+// ParseDayNumber exactly converts a decimal day number such as "2459282.17" into a UTC time
+func ParseDayNumber(s string, system DayNumberSystem) (t time.Time, err error) {
+	// days are handled like seconds so the fraction is kept to nine digits exactly
+	d, err := ParseUnixDecimal(s)
+	if err != nil {
+		return t, fmt.Errorf("invalid %s %q", system, strings.TrimSpace(s))
+	}
+	return time.Unix(system.epoch()+d.Unix()*86400, int64(d.Nanosecond())*86400).UTC(), nil
+}
+
+// ParseDayNumberString converts a prefixed day number such as "JD 2459282.17" or "MJD 59281.67" into a UTC time
+func ParseDayNumberString(dtz string) (t time.Time, err error) {
+	matches := reDayNumber.FindStringSubmatch(strings.TrimSpace(dtz))
+	if matches == nil {
+		return t, fmt.Errorf("invalid day number %q", dtz)
+	}
+	system, err := ParseDayNumberSystem(matches[1])
+	if err != nil {
+		return t, err
+	}
+	return ParseDayNumber(matches[2], system)
+}
+
+// GetDayNumberFormat determines the correct format for the provided day number string
+func GetDayNumberFormat(dtz string) (format string) {
+	matches := reDayNumber.FindStringSubmatch(strings.TrimSpace(dtz))
+	if matches != nil {
+		DebugPrintf("chronus.GetDayNumberFormat() | day number matched: true | matches: %q\n", matches)
+		system, _ := ParseDayNumberSystem(matches[1])
+		format = system.String()
+	}
+	return format
+}
+
+// JulianDayNumber converts t into a fractional Julian Day
+func JulianDayNumber(t time.Time) float64 {
+	return DayNumber(t, JulianDay)
+}
+
+// JulianDayToTime converts a fractional Julian Day into a UTC time
+func JulianDayToTime(jd float64) time.Time {
+	return DayNumberToTime(jd, JulianDay)
+}
+
+// ModifiedJulianDate converts t into a fractional Modified Julian Date
+func ModifiedJulianDate(t time.Time) float64 {
+	return DayNumber(t, ModifiedJulianDay)
+}
+
+// ModifiedJulianDateToTime converts a fractional Modified Julian Date into a UTC time
+func ModifiedJulianDateToTime(mjd float64) time.Time {
+	return DayNumberToTime(mjd, ModifiedJulianDay)
+}
+
+// RataDieNumber converts t into a fractional Rata Die day number
+func RataDieNumber(t time.Time) float64 {
+	return DayNumber(t, RataDie)
+}
+
+// RataDieToTime converts a fractional Rata Die day number into a UTC time
+func RataDieToTime(rd float64) time.Time {
+	return DayNumberToTime(rd, RataDie)
+}
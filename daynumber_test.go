@@ -0,0 +1,48 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayNumberEpochs(t *testing.T) {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		system DayNumberSystem
+		zero   time.Time // day number 0
+		j2000  string
+	}{
+		{JulianDay, time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC), "2451545.0"},
+		{ModifiedJulianDay, time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), "51544.5"},
+		{ReducedJulianDay, time.Date(1858, 11, 16, 12, 0, 0, 0, time.UTC), "51545.0"},
+		{TruncatedJulianDay, time.Date(1968, 5, 24, 0, 0, 0, 0, time.UTC), "11544.5"},
+		{RataDie, time.Date(0, 12, 31, 0, 0, 0, 0, time.UTC), "730120.5"},
+	}
+	for _, tt := range tests {
+		if got := DayNumberString(tt.zero, tt.system, 1); got != "0.0" {
+			t.Errorf("%s of %s = %s, want 0.0", tt.system.Abbr(), tt.zero.Format(time.RFC3339), got)
+		}
+		if got := DayNumberString(j2000, tt.system, 1); got != tt.j2000 {
+			t.Errorf("%s of J2000 = %s, want %s", tt.system.Abbr(), got, tt.j2000)
+		}
+		got, err := ParseDayNumberString(tt.system.Abbr() + " " + tt.j2000)
+		if err != nil || !got.Equal(j2000) {
+			t.Errorf("ParseDayNumberString(%s %s) = %s, %v, want %s", tt.system.Abbr(), tt.j2000, got, err, j2000)
+		}
+	}
+}
+
+func TestDayNumberFraction(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+	if got := DayNumberString(ts, ModifiedJulianDay, 8); got != "59281.67122685" {
+		t.Errorf("MJD of %s = %s, want 59281.67122685", ts, got)
+	}
+	// the ninth digit of a day is 86.4 microseconds
+	got, err := ParseDayNumber("59281.671226851", ModifiedJulianDay)
+	if err != nil || got.Sub(ts) > 87*time.Microsecond || ts.Sub(got) > 87*time.Microsecond {
+		t.Errorf("ParseDayNumber(59281.671226851) = %s, %v, want %s", got, err, ts)
+	}
+	if _, err := ParseDayNumberString("XJD 1.5"); err == nil {
+		t.Error("ParseDayNumberString(XJD 1.5) did not fail")
+	}
+}
@@ -0,0 +1,807 @@
+package chronus
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// EmbeddedTime is a timestamp stored inside a file's metadata or headers
+type EmbeddedTime struct {
+	Source string // where it was found: EXIF, PNG tEXt, PNG tIME, PDF Info, ZIP, tar, or gzip
+	Field  string // the tag, keyword, key, or archive member it belongs to
+	Raw    string // the stored text when the timestamp is text
+	Time   time.Time
+}
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+	rePDFInfoLiteral = regexp.MustCompile(`/(CreationDate|ModDate)\s*\(([^)]*)\)`)
+	rePDFInfoHex     = regexp.MustCompile(`/(CreationDate|ModDate)\s*<([0-9A-Fa-f\s]*)>`)
+)
+
+// captureFields are the embedded times that record when an image was taken or a
+// document created, most reliable first
+var captureFields = []struct{ source, field string }{
+	{"EXIF", "DateTimeOriginal"},
+	{"EXIF", "DateTimeDigitized"},
+	{"EXIF", "GPSDateStamp GPSTimeStamp"},
+	{"EXIF", "DateTime"},
+	{"PNG tEXt", "Creation Time"},
+	{"PNG iTXt", "Creation Time"},
+	{"PNG zTXt", "Creation Time"},
+	{"PDF Info", "CreationDate"},
+}
+
+// CaptureTime returns the embedded time that best records when the file's
+// content was captured or created, preferring EXIF DateTimeOriginal
+func CaptureTime(times []EmbeddedTime) (et EmbeddedTime, ok bool) {
+	for _, cf := range captureFields {
+		for _, et = range times {
+			if et.Source == cf.source && et.Field == cf.field {
+				return et, true
+			}
+		}
+	}
+	return EmbeddedTime{}, false
+}
+
+// ReadEmbeddedTimes opens the file at path and returns the timestamps embedded in it
+func ReadEmbeddedTimes(path string) (times []EmbeddedTime, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if times, err = EmbeddedTimes(f, info.Size()); err != nil {
+		return times, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return times, nil
+}
+
+// EmbeddedTimes returns the capture, creation, and modification timestamps in
+// JPEG and TIFF EXIF, PNG text and tIME chunks, PDF Info dictionaries, and the
+// member headers of ZIP, tar, and gzip (including .tar.gz) archives
+func EmbeddedTimes(r io.ReaderAt, size int64) (times []EmbeddedTime, err error) {
+	head := make([]byte, 512)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+	section := io.NewSectionReader(r, 0, size)
+
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return jpegTimes(section)
+	case bytes.HasPrefix(head, []byte("II*\x00")), bytes.HasPrefix(head, []byte("MM\x00*")):
+		return exifTimes(r, size)
+	case bytes.HasPrefix(head, pngSignature):
+		return pngTimes(section)
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return pdfTimes(section)
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")), bytes.HasPrefix(head, []byte("PK\x05\x06")):
+		return zipTimes(r, size)
+	case bytes.HasPrefix(head, []byte{0x1F, 0x8B}):
+		return gzipTimes(section)
+	case isTarHeader(head):
+		return tarTimes(section)
+	}
+
+	return nil, fmt.Errorf("unrecognized file type (expected JPEG, TIFF, PNG, PDF, ZIP, tar, or gzip)")
+}
+
+// jpegTimes reads the EXIF in the APP1 segments of a JPEG, reading only the
+// segment headers and the Exif payloads up to the start of the image data
+func jpegTimes(r *io.SectionReader) (times []EmbeddedTime, err error) {
+	size := r.Size()
+	head := make([]byte, 10)
+	pos := int64(2)
+	for pos+4 <= size {
+		if _, err = r.ReadAt(head[:4], pos); err != nil {
+			return times, err
+		}
+		if head[0] != 0xFF {
+			return times, fmt.Errorf("invalid JPEG marker at offset %d", pos)
+		}
+		marker := head[1]
+		switch {
+		case marker == 0xFF:
+			pos++ // fill byte
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD8):
+			pos += 2 // markers without a length
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			// start of scan or end of image, the metadata segments come before it
+			return times, nil
+		}
+		end := pos + 2 + int64(binary.BigEndian.Uint16(head[2:]))
+		if end > size {
+			return times, fmt.Errorf("truncated JPEG segment at offset %d", pos)
+		}
+		if marker == 0xE1 && end-pos >= 10 {
+			if _, err = r.ReadAt(head, pos); err != nil {
+				return times, err
+			}
+			if string(head[4:]) == "Exif\x00\x00" {
+				exif, err := exifTimes(io.NewSectionReader(r, pos+10, end-pos-10), end-pos-10)
+				if err != nil {
+					return times, err
+				}
+				times = append(times, exif...)
+			}
+		}
+		pos = end
+	}
+
+	return times, nil
+}
+
+// maxPNGText bounds the text chunks read, which for dates are a few bytes
+const maxPNGText = 1 << 16
+
+// pngTimes reads the tIME and eXIf chunks and the date-like tEXt, zTXt, and
+// iTXt keywords (such as "Creation Time" and ImageMagick's "date:create") of a
+// PNG, skipping over the other chunks without reading them
+func pngTimes(r *io.SectionReader) (times []EmbeddedTime, err error) {
+	size := r.Size()
+	header := make([]byte, 8)
+	pos := int64(len(pngSignature))
+	for pos+12 <= size {
+		if _, err = r.ReadAt(header, pos); err != nil {
+			return times, err
+		}
+		length := int64(binary.BigEndian.Uint32(header))
+		kind := string(header[4:])
+		if pos+12+length > size {
+			return times, fmt.Errorf("truncated PNG %s chunk at offset %d", kind, pos)
+		}
+		data := pos + 8
+		pos += 12 + length
+
+		switch kind {
+		case "tIME":
+			if length != 7 {
+				continue
+			}
+			chunk := make([]byte, 7)
+			if _, err = r.ReadAt(chunk, data); err != nil {
+				return times, err
+			}
+			t := time.Date(int(binary.BigEndian.Uint16(chunk)), time.Month(chunk[2]), int(chunk[3]),
+				int(chunk[4]), int(chunk[5]), int(chunk[6]), 0, time.UTC)
+			times = append(times, EmbeddedTime{Source: "PNG tIME", Field: "Last Modified", Time: t})
+		case "eXIf":
+			exif, err := exifTimes(io.NewSectionReader(r, data, length), length)
+			if err != nil {
+				return times, err
+			}
+			times = append(times, exif...)
+		case "tEXt", "zTXt", "iTXt":
+			// keywords are at most 79 bytes, so check it before reading the text
+			peek := make([]byte, 80)
+			if length < int64(len(peek)) {
+				peek = peek[:length]
+			}
+			if _, err = r.ReadAt(peek, data); err != nil {
+				return times, err
+			}
+			if i := bytes.IndexByte(peek, 0); i < 0 || !isDateKeyword(string(peek[:i])) || length > maxPNGText {
+				continue
+			}
+			chunk := make([]byte, length)
+			if _, err = r.ReadAt(chunk, data); err != nil {
+				return times, err
+			}
+			keyword, text, ok := pngText(kind, chunk)
+			if !ok {
+				continue
+			}
+			if t, err := Parse(text); err == nil {
+				times = append(times, EmbeddedTime{Source: "PNG " + kind, Field: keyword, Raw: text, Time: t})
+			} else {
+				DebugPrintf("chronus.pngTimes() | %s: %s\n", keyword, err.Error())
+			}
+		case "IEND":
+			return times, nil
+		}
+	}
+
+	return times, nil
+}
+
+// isDateKeyword reports whether a PNG text keyword names a date or time
+func isDateKeyword(keyword string) bool {
+	lower := strings.ToLower(keyword)
+	return strings.Contains(lower, "date") || strings.Contains(lower, "time")
+}
+
+// pngText returns the keyword and (decompressed) text of a PNG text chunk
+func pngText(kind string, chunk []byte) (keyword, text string, ok bool) {
+	i := bytes.IndexByte(chunk, 0)
+	if i < 0 {
+		return "", "", false
+	}
+	keyword, rest := string(chunk[:i]), chunk[i+1:]
+	compressed := false
+
+	switch kind {
+	case "zTXt":
+		if len(rest) < 1 {
+			return "", "", false
+		}
+		rest, compressed = rest[1:], true
+	case "iTXt":
+		// compression flag, compression method, language tag, translated keyword
+		if len(rest) < 2 {
+			return "", "", false
+		}
+		compressed = rest[0] == 1
+		rest = rest[2:]
+		for n := 0; n < 2; n++ {
+			j := bytes.IndexByte(rest, 0)
+			if j < 0 {
+				return "", "", false
+			}
+			rest = rest[j+1:]
+		}
+	}
+	if compressed {
+		zr, err := zlib.NewReader(bytes.NewReader(rest))
+		if err != nil {
+			return "", "", false
+		}
+		defer zr.Close()
+		if rest, err = io.ReadAll(io.LimitReader(zr, 1<<16)); err != nil {
+			return "", "", false
+		}
+	}
+
+	return keyword, strings.TrimSpace(string(rest)), true
+}
+
+const (
+	// maxPDFObject bounds the bytes read for an Info dictionary, a trailer, or a stream dictionary
+	maxPDFObject = 1 << 14
+
+	// maxPDFStream bounds the (decompressed) cross-reference and object streams
+	maxPDFStream = 1 << 24
+
+	// maxPDFUpdates bounds the chain of cross-reference sections followed through /Prev
+	maxPDFUpdates = 256
+)
+
+// pdfXref is a cross-reference section of a PDF with its trailer entries.
+// A classic xref table is looked up in place, an xref stream is decoded.
+type pdfXref struct {
+	subsections []pdfSubsection // classic table
+	entries     []byte          // xref stream rows
+	widths      [3]int
+	index       []int
+	info        int // object number of the Info dictionary, 0 when there is none
+	prev        int64
+}
+
+// pdfSubsection is a run of fixed size xref table entries starting at pos
+type pdfSubsection struct {
+	start, count int
+	pos          int64
+}
+
+var (
+	rePDFStartXref  = regexp.MustCompile(`startxref\s+(\d+)`)
+	rePDFSubsection = regexp.MustCompile(`^\s*(\d+)\s+(\d+)[ \t]*(\r\n|\r|\n)`)
+	rePDFObject     = regexp.MustCompile(`^\s*(\d+)\s+\d+\s+obj\b`)
+	rePDFInfo       = regexp.MustCompile(`/Info\s+(\d+)\s+\d+\s+R`)
+	rePDFPrev       = regexp.MustCompile(`/Prev\s+(\d+)`)
+	rePDFLength     = regexp.MustCompile(`/Length\s+(\d+)(\s+\d+\s+R)?`)
+	rePDFWidths     = regexp.MustCompile(`/W\s*\[\s*(\d+)\s+(\d+)\s+(\d+)\s*\]`)
+	rePDFIndex      = regexp.MustCompile(`/Index\s*\[([\d\s]*)\]`)
+	rePDFSize       = regexp.MustCompile(`/Size\s+(\d+)`)
+	rePDFFlate      = regexp.MustCompile(`/Filter\s*(\[\s*)?/FlateDecode\s*\]?`)
+	rePDFPredictor  = regexp.MustCompile(`/Predictor\s+(\d+)`)
+	rePDFColumns    = regexp.MustCompile(`/Columns\s+(\d+)`)
+	rePDFFirst      = regexp.MustCompile(`/First\s+(\d+)`)
+)
+
+// pdfTimes reads the CreationDate and ModDate entries of the Info dictionaries
+// named by the trailers of a PDF, including those of incremental updates. Only
+// the end of the file, the cross-reference sections, and the Info objects are read.
+func pdfTimes(r *io.SectionReader) (times []EmbeddedTime, err error) {
+	offset, err := pdfStartXref(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []pdfXref
+	seenXref := map[int64]bool{}
+	for offset > 0 && !seenXref[offset] && len(sections) < maxPDFUpdates {
+		seenXref[offset] = true
+		x, err := readPDFXref(r, offset)
+		if err != nil {
+			if len(sections) == 0 {
+				return nil, err
+			}
+			DebugPrintf("chronus.pdfTimes() | %s\n", err.Error())
+			break
+		}
+		sections = append(sections, x)
+		offset = x.prev
+	}
+
+	seen := map[string]bool{}
+	add := func(key, raw string) {
+		if seen[key+raw] {
+			return
+		}
+		seen[key+raw] = true
+		t, err := ParsePDFDate(raw)
+		if err != nil {
+			DebugPrintf("chronus.pdfTimes() | %s: %s\n", key, err.Error())
+			return
+		}
+		times = append(times, EmbeddedTime{Source: "PDF Info", Field: key, Raw: raw, Time: t})
+	}
+
+	for i, x := range sections {
+		if x.info == 0 {
+			continue
+		}
+		// the Info object as it was when this section was written
+		info, err := pdfObject(r, sections[i:], x.info)
+		if err != nil {
+			DebugPrintf("chronus.pdfTimes() | Info: %s\n", err.Error())
+			continue
+		}
+		for _, m := range rePDFInfoLiteral.FindAllSubmatch(info, -1) {
+			add(string(m[1]), string(m[2]))
+		}
+		for _, m := range rePDFInfoHex.FindAllSubmatch(info, -1) {
+			b, err := hex.DecodeString(strings.Join(strings.Fields(string(m[2])), ""))
+			if err != nil {
+				continue
+			}
+			add(string(m[1]), pdfTextString(b))
+		}
+	}
+
+	return times, nil
+}
+
+// readPDF returns up to n bytes of r from offset
+func readPDF(r *io.SectionReader, offset int64, n int) []byte {
+	if offset < 0 || offset >= r.Size() {
+		return nil
+	}
+	if rest := r.Size() - offset; int64(n) > rest {
+		n = int(rest)
+	}
+	b := make([]byte, n)
+	n, _ = r.ReadAt(b, offset)
+	return b[:n]
+}
+
+// pdfStartXref returns the offset of the last cross-reference section from the end of the file
+func pdfStartXref(r *io.SectionReader) (int64, error) {
+	tail := int64(1024)
+	if tail > r.Size() {
+		tail = r.Size()
+	}
+	matches := rePDFStartXref.FindAllSubmatch(readPDF(r, r.Size()-tail, int(tail)), -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("PDF startxref not found")
+	}
+	return strconv.ParseInt(string(matches[len(matches)-1][1]), 10, 64)
+}
+
+// readPDFXref reads the classic xref table or xref stream at offset
+func readPDFXref(r *io.SectionReader, offset int64) (x pdfXref, err error) {
+	window := readPDF(r, offset, maxPDFObject)
+	trimmed := bytes.TrimLeft(window, " \t\r\n\f\x00")
+	if !bytes.HasPrefix(trimmed, []byte("xref")) {
+		return readPDFXrefStream(r, offset)
+	}
+
+	pos := offset + int64(len(window)-len(trimmed)) + 4
+	for pos < r.Size() {
+		head := readPDF(r, pos, 64)
+		if m := rePDFSubsection.FindSubmatchIndex(head); m != nil {
+			start, _ := strconv.Atoi(string(head[m[2]:m[3]]))
+			count, _ := strconv.Atoi(string(head[m[4]:m[5]]))
+			x.subsections = append(x.subsections, pdfSubsection{start: start, count: count, pos: pos + int64(m[1])})
+			pos += int64(m[1]) + int64(count)*20
+			continue
+		}
+		if !bytes.HasPrefix(bytes.TrimLeft(head, " \t\r\n\f"), []byte("trailer")) {
+			break
+		}
+		trailer := readPDF(r, pos, maxPDFObject)
+		if i := bytes.Index(trailer, []byte("startxref")); i >= 0 {
+			trailer = trailer[:i]
+		}
+		x.info, x.prev = pdfTrailer(trailer)
+		return x, nil
+	}
+
+	return x, fmt.Errorf("invalid PDF xref table at offset %d", offset)
+}
+
+// readPDFXrefStream reads the PDF 1.5 cross-reference stream at offset
+func readPDFXrefStream(r *io.SectionReader, offset int64) (x pdfXref, err error) {
+	dict, data, err := pdfStream(r, offset)
+	if err != nil {
+		return x, err
+	}
+	w := rePDFWidths.FindSubmatch(dict)
+	if w == nil || !bytes.Contains(dict, []byte("/XRef")) {
+		return x, fmt.Errorf("no PDF xref table or stream at offset %d", offset)
+	}
+	for i := range x.widths {
+		x.widths[i], _ = strconv.Atoi(string(w[i+1]))
+		if x.widths[i] > 8 {
+			return x, fmt.Errorf("invalid PDF xref stream field width %d", x.widths[i])
+		}
+	}
+	if m := rePDFIndex.FindSubmatch(dict); m != nil {
+		for _, f := range strings.Fields(string(m[1])) {
+			n, _ := strconv.Atoi(f)
+			x.index = append(x.index, n)
+		}
+	} else if m := rePDFSize.FindSubmatch(dict); m != nil {
+		n, _ := strconv.Atoi(string(m[1]))
+		x.index = []int{0, n}
+	}
+	x.entries = data
+	x.info, x.prev = pdfTrailer(dict)
+	return x, nil
+}
+
+// pdfTrailer returns the Info object number and the offset of the previous section of a trailer dictionary
+func pdfTrailer(dict []byte) (info int, prev int64) {
+	if m := rePDFInfo.FindSubmatch(dict); m != nil {
+		info, _ = strconv.Atoi(string(m[1]))
+	}
+	if m := rePDFPrev.FindSubmatch(dict); m != nil {
+		prev, _ = strconv.ParseInt(string(m[1]), 10, 64)
+	}
+	return info, prev
+}
+
+// entry looks up an object in the section returning its type (0 free, 1 at
+// offset a, 2 number b in object stream a) and whether the section has it
+func (x pdfXref) entry(r *io.SectionReader, num int) (kind int, a, b int64, ok bool) {
+	for _, sub := range x.subsections {
+		if num < sub.start || num >= sub.start+sub.count {
+			continue
+		}
+		e := readPDF(r, sub.pos+int64(num-sub.start)*20, 18)
+		if len(e) < 18 {
+			return 0, 0, 0, false
+		}
+		a, err := strconv.ParseInt(string(e[:10]), 10, 64)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		if e[17] == 'n' {
+			return 1, a, 0, true
+		}
+		return 0, 0, 0, true
+	}
+
+	row, rowLen := 0, x.widths[0]+x.widths[1]+x.widths[2]
+	for i := 0; i+1 < len(x.index); i += 2 {
+		start, count := x.index[i], x.index[i+1]
+		if num < start || num >= start+count {
+			row += count
+			continue
+		}
+		pos := (row + num - start) * rowLen
+		if pos+rowLen > len(x.entries) {
+			return 0, 0, 0, false
+		}
+		var fields [3]int64
+		for f, width := range x.widths {
+			for _, c := range x.entries[pos : pos+width] {
+				fields[f] = fields[f]<<8 | int64(c)
+			}
+			pos += width
+		}
+		if x.widths[0] == 0 {
+			fields[0] = 1
+		}
+		return int(fields[0]), fields[1], fields[2], true
+	}
+	return 0, 0, 0, false
+}
+
+// pdfLocate finds an object in the newest of the sections that has it
+func pdfLocate(r *io.SectionReader, sections []pdfXref, num int) (kind int, a, b int64, err error) {
+	for _, x := range sections {
+		if kind, a, b, ok := x.entry(r, num); ok {
+			if kind == 0 {
+				return 0, 0, 0, fmt.Errorf("PDF object %d is free", num)
+			}
+			return kind, a, b, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("PDF object %d is not in the xref", num)
+}
+
+// pdfObject returns the start of object num up to its endobj
+func pdfObject(r *io.SectionReader, sections []pdfXref, num int) ([]byte, error) {
+	kind, a, b, err := pdfLocate(r, sections, num)
+	if err != nil {
+		return nil, err
+	}
+	if kind == 2 {
+		return pdfStreamObject(r, sections, int(a), int(b))
+	}
+
+	obj := readPDF(r, a, maxPDFObject)
+	if m := rePDFObject.FindSubmatch(obj); m == nil || string(m[1]) != strconv.Itoa(num) {
+		return nil, fmt.Errorf("PDF object %d not found at offset %d", num, a)
+	}
+	if i := bytes.Index(obj, []byte("endobj")); i >= 0 {
+		obj = obj[:i]
+	}
+	return obj, nil
+}
+
+// pdfStreamObject returns object number i of the object stream stm
+func pdfStreamObject(r *io.SectionReader, sections []pdfXref, stm, i int) ([]byte, error) {
+	kind, offset, _, err := pdfLocate(r, sections, stm)
+	if err != nil {
+		return nil, err
+	}
+	if kind != 1 {
+		return nil, fmt.Errorf("PDF object stream %d is itself compressed", stm)
+	}
+	dict, data, err := pdfStream(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	m := rePDFFirst.FindSubmatch(dict)
+	if m == nil {
+		return nil, fmt.Errorf("PDF object stream %d has no /First", stm)
+	}
+	first, _ := strconv.Atoi(string(m[1]))
+	if first > len(data) {
+		return nil, fmt.Errorf("PDF object stream %d is truncated", stm)
+	}
+
+	// pairs of object number and offset from first
+	header := strings.Fields(string(data[:first]))
+	if 2*i+1 >= len(header) {
+		return nil, fmt.Errorf("PDF object stream %d has no object %d", stm, i)
+	}
+	start, _ := strconv.Atoi(header[2*i+1])
+	end := len(data) - first
+	if 2*i+3 < len(header) {
+		end, _ = strconv.Atoi(header[2*i+3])
+	}
+	if start < 0 || start > end || first+end > len(data) {
+		return nil, fmt.Errorf("PDF object stream %d is truncated", stm)
+	}
+	return data[first+start : first+end], nil
+}
+
+// pdfStream returns the dictionary and decoded data of the stream object at
+// offset. Only FlateDecode with PNG predictors and a direct /Length are handled.
+func pdfStream(r *io.SectionReader, offset int64) (dict, data []byte, err error) {
+	window := readPDF(r, offset, maxPDFObject)
+	i := bytes.Index(window, []byte("stream"))
+	if !rePDFObject.Match(window) || i < 0 {
+		return nil, nil, fmt.Errorf("no PDF stream at offset %d", offset)
+	}
+	dict = window[:i]
+	start := offset + int64(i) + 6
+	if rest := window[i+6:]; bytes.HasPrefix(rest, []byte("\r\n")) {
+		start += 2
+	} else if bytes.HasPrefix(rest, []byte("\n")) {
+		start++
+	}
+
+	m := rePDFLength.FindSubmatch(dict)
+	if m == nil || len(m[2]) > 0 {
+		return nil, nil, fmt.Errorf("PDF stream at offset %d has no direct /Length", offset)
+	}
+	length, _ := strconv.ParseInt(string(m[1]), 10, 64)
+	if length > maxPDFStream || start+length > r.Size() {
+		return nil, nil, fmt.Errorf("PDF stream at offset %d is too long", offset)
+	}
+	data = make([]byte, length)
+	if _, err = r.ReadAt(data, start); err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case rePDFFlate.Match(dict):
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(io.LimitReader(zr, maxPDFStream)); err != nil {
+			return nil, nil, err
+		}
+	case bytes.Contains(dict, []byte("/Filter")):
+		return nil, nil, fmt.Errorf("unsupported PDF stream filter at offset %d", offset)
+	}
+
+	if m := rePDFPredictor.FindSubmatch(dict); m != nil {
+		predictor, _ := strconv.Atoi(string(m[1]))
+		columns := 1
+		if m := rePDFColumns.FindSubmatch(dict); m != nil {
+			columns, _ = strconv.Atoi(string(m[1]))
+		}
+		switch {
+		case predictor >= 10:
+			data, err = pngUnpredict(data, columns)
+		case predictor > 1:
+			err = fmt.Errorf("unsupported PDF predictor %d", predictor)
+		}
+	}
+
+	return dict, data, err
+}
+
+// pngUnpredict reverses the PNG row filters that PDF streams use with one byte per column
+func pngUnpredict(data []byte, columns int) ([]byte, error) {
+	rowLen := columns + 1
+	if columns < 1 || len(data)%rowLen != 0 {
+		return nil, fmt.Errorf("PDF predictor rows do not fit %d columns", columns)
+	}
+	out := make([]byte, 0, len(data)/rowLen*columns)
+	prev := make([]byte, columns)
+	for pos := 0; pos < len(data); pos += rowLen {
+		filter, row := data[pos], data[pos+1:pos+rowLen]
+		for i := range row {
+			var left, upLeft int
+			if i > 0 {
+				left, upLeft = int(row[i-1]), int(prev[i-1])
+			}
+			up := int(prev[i])
+			switch filter {
+			case 0:
+			case 1:
+				row[i] += byte(left)
+			case 2:
+				row[i] += byte(up)
+			case 3:
+				row[i] += byte((left + up) / 2)
+			case 4:
+				// Paeth predictor
+				p := left + up - upLeft
+				pa, pb, pc := absInt(p-left), absInt(p-up), absInt(p-upLeft)
+				switch {
+				case pa <= pb && pa <= pc:
+					row[i] += byte(left)
+				case pb <= pc:
+					row[i] += byte(up)
+				default:
+					row[i] += byte(upLeft)
+				}
+			default:
+				return nil, fmt.Errorf("invalid PNG filter type %d", filter)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// pdfTextString decodes a PDF text string which is UTF-16BE when it starts with a byte order mark
+func pdfTextString(b []byte) string {
+	if !bytes.HasPrefix(b, []byte{0xFE, 0xFF}) {
+		return string(b)
+	}
+	b = b[2:]
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.BigEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
+
+// zipTimes reads the modification time of each ZIP member. ZIP files without the
+// extended timestamp field store a local wall clock which is reported as UTC.
+func zipTimes(r io.ReaderAt, size int64) (times []EmbeddedTime, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if !f.Modified.IsZero() {
+			times = append(times, EmbeddedTime{Source: "ZIP", Field: f.Name, Time: f.Modified})
+		}
+	}
+
+	return times, nil
+}
+
+// isTarHeader reports whether head starts with a POSIX or GNU tar header
+func isTarHeader(head []byte) bool {
+	return len(head) >= 262 && string(head[257:262]) == "ustar"
+}
+
+// tarTimes reads the modification, access, and change times of each tar member
+func tarTimes(r io.Reader) (times []EmbeddedTime, err error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return times, nil
+		}
+		if err != nil {
+			return times, err
+		}
+		for _, member := range []struct {
+			suffix string
+			t      time.Time
+		}{
+			{"", hdr.ModTime},
+			{" (access)", hdr.AccessTime},
+			{" (change)", hdr.ChangeTime},
+		} {
+			if !member.t.IsZero() {
+				times = append(times, EmbeddedTime{Source: "tar", Field: hdr.Name + member.suffix, Time: member.t})
+			}
+		}
+	}
+}
+
+// gzipTimes reads the MTIME of a gzip header and the members of a compressed tar
+func gzipTimes(r io.Reader) (times []EmbeddedTime, err error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	if !zr.ModTime.IsZero() {
+		field := "MTIME"
+		if len(zr.Name) > 0 {
+			field = zr.Name
+		}
+		times = append(times, EmbeddedTime{Source: "gzip", Field: field, Time: zr.ModTime})
+	}
+
+	br := bufio.NewReaderSize(zr, 512)
+	head, _ := br.Peek(512)
+	if isTarHeader(head) {
+		members, err := tarTimes(br)
+		times = append(times, members...)
+		return times, err
+	}
+
+	return times, nil
+}
@@ -0,0 +1,349 @@
+package chronus
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+	"time"
+)
+
+// countingReaderAt counts the bytes read to show the parsers skip the bulk of a file
+type countingReaderAt struct {
+	r io.ReaderAt
+	n int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.n += int64(n)
+	return n, err
+}
+
+// embeddedTimes runs EmbeddedTimes over data and returns the times as
+// "Source Field=RFC3339" with the number of bytes read
+func embeddedTimes(t *testing.T, data []byte) (got []string, read int64) {
+	t.Helper()
+	r := &countingReaderAt{r: bytes.NewReader(data)}
+	times, err := EmbeddedTimes(r, int64(len(data)))
+	if err != nil {
+		t.Fatalf("EmbeddedTimes() error: %s", err)
+	}
+	for _, et := range times {
+		got = append(got, et.Source+" "+et.Field+"="+et.Time.Format(time.RFC3339Nano))
+	}
+	return got, r.n
+}
+
+func wantTimes(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("embedded times = %q, want %q", got, want)
+	}
+}
+
+// tiffTag is an IFD entry for tiffFixture
+type tiffTag struct {
+	tag, typ uint16
+	count    uint32
+	value    []byte
+}
+
+func asciiTag(tag uint16, s string) tiffTag {
+	return tiffTag{tag, 2, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+// tiffFixture builds little endian TIFF data with IFD0 pointing to the EXIF and
+// GPS IFDs, which are left out when empty
+func tiffFixture(ifd0, exif, gps []tiffTag) []byte {
+	le := binary.LittleEndian
+	ifds := [][]tiffTag{ifd0, exif, gps}
+	offsets := make([]uint32, 3)
+	pos := uint32(8)
+	for i, ifd := range ifds {
+		if i > 0 && len(ifd) == 0 {
+			continue
+		}
+		n := len(ifd)
+		if i == 0 {
+			n += 2 // the EXIF and GPS pointers
+		}
+		offsets[i] = pos
+		pos += 2 + 12*uint32(n) + 4
+	}
+	ifds[0] = append(ifds[0], tiffTag{0x8769, 4, 1, nil}, tiffTag{0x8825, 4, 1, nil})
+
+	var head, tail bytes.Buffer
+	head.WriteString("II*\x00")
+	binary.Write(&head, le, uint32(8))
+	for i, ifd := range ifds {
+		if offsets[i] == 0 {
+			continue
+		}
+		binary.Write(&head, le, uint16(len(ifd)))
+		for j, e := range ifd {
+			value := e.value
+			if i == 0 && j >= len(ifd)-2 {
+				value = make([]byte, 4)
+				le.PutUint32(value, offsets[j-len(ifd)+3])
+			}
+			binary.Write(&head, le, e.tag)
+			binary.Write(&head, le, e.typ)
+			binary.Write(&head, le, e.count)
+			if len(value) <= 4 {
+				head.Write(append(value, make([]byte, 4-len(value))...))
+			} else {
+				binary.Write(&head, le, pos+uint32(tail.Len()))
+				tail.Write(value)
+			}
+		}
+		binary.Write(&head, le, uint32(0))
+	}
+	return append(head.Bytes(), tail.Bytes()...)
+}
+
+func exifFixture() []byte {
+	gpsTime := make([]byte, 24)
+	for i, v := range []uint32{23, 1, 6, 1, 3405, 100} {
+		binary.LittleEndian.PutUint32(gpsTime[i*4:], v)
+	}
+	return tiffFixture(
+		[]tiffTag{asciiTag(0x0132, "2021:03:09 08:00:00")},
+		[]tiffTag{
+			asciiTag(0x9003, "2021:03:08 16:06:34"),
+			asciiTag(0x9011, "-07:00"),
+			asciiTag(0x9291, "5"),
+		},
+		[]tiffTag{
+			asciiTag(0x001D, "2021:03:08"),
+			{0x0007, 5, 3, gpsTime},
+		},
+	)
+}
+
+var exifWant = []string{
+	"EXIF DateTime=2021-03-09T08:00:00Z",
+	"EXIF DateTimeOriginal=2021-03-08T16:06:34.5-07:00",
+	"EXIF GPSDateStamp GPSTimeStamp=2021-03-08T23:06:34.05Z",
+}
+
+func TestEmbeddedTimesTIFF(t *testing.T) {
+	got, _ := embeddedTimes(t, exifFixture())
+	wantTimes(t, got, exifWant...)
+
+	// a count past the end of the data is ignored rather than read
+	bad := exifFixture()
+	binary.LittleEndian.PutUint32(bad[8+2+4:], 1<<30)
+	got, _ = embeddedTimes(t, bad)
+	wantTimes(t, got, exifWant[1:]...)
+}
+
+func TestEmbeddedTimesJPEG(t *testing.T) {
+	segment := func(marker byte, payload []byte) []byte {
+		b := []byte{0xFF, marker, 0, 0}
+		binary.BigEndian.PutUint16(b[2:], uint16(len(payload)+2))
+		return append(b, payload...)
+	}
+	var jpeg bytes.Buffer
+	jpeg.Write([]byte{0xFF, 0xD8})
+	jpeg.Write(segment(0xE0, []byte("JFIF\x00\x01\x02")))
+	jpeg.Write(segment(0xE1, append([]byte("Exif\x00\x00"), exifFixture()...)))
+	jpeg.Write(segment(0xDA, []byte{0, 0, 0}))
+	// image data, which is never read, with what looks like another Exif segment
+	jpeg.Write(bytes.Repeat([]byte{0x55}, 1<<20))
+	jpeg.Write(segment(0xE1, append([]byte("Exif\x00\x00"), exifFixture()...)))
+	jpeg.Write([]byte{0xFF, 0xD9})
+
+	got, read := embeddedTimes(t, jpeg.Bytes())
+	wantTimes(t, got, exifWant...)
+	if read > 4096 {
+		t.Errorf("read %d bytes of the JPEG, want the headers and Exif only", read)
+	}
+}
+
+func TestEmbeddedTimesPNG(t *testing.T) {
+	var png bytes.Buffer
+	chunk := func(kind string, data []byte) {
+		binary.Write(&png, binary.BigEndian, uint32(len(data)))
+		png.WriteString(kind)
+		png.Write(data)
+		png.Write(make([]byte, 4)) // the CRC is not checked
+	}
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write([]byte("2021-03-08T16:06:34-07:00"))
+	zw.Close()
+
+	png.Write(pngSignature)
+	chunk("IHDR", make([]byte, 13))
+	chunk("tEXt", []byte("Creation Time\x002021-03-08T16:06:34Z"))
+	chunk("tEXt", []byte("Comment\x00not a date"))
+	chunk("zTXt", append([]byte("date:modify\x00\x00"), z.Bytes()...))
+	chunk("iTXt", []byte("date:create\x00\x00\x00en\x00\x002021-03-07T10:00:00Z"))
+	chunk("IDAT", make([]byte, 1<<20))
+	chunk("tIME", []byte{0x07, 0xE5, 3, 8, 23, 6, 34})
+	chunk("IEND", nil)
+	chunk("tIME", []byte{0x07, 0xE5, 3, 9, 0, 0, 0})
+
+	got, read := embeddedTimes(t, png.Bytes())
+	wantTimes(t, got,
+		"PNG tEXt Creation Time=2021-03-08T16:06:34Z",
+		"PNG zTXt date:modify=2021-03-08T16:06:34-07:00",
+		"PNG iTXt date:create=2021-03-07T10:00:00Z",
+		"PNG tIME Last Modified=2021-03-08T23:06:34Z",
+	)
+	if read > 4096 {
+		t.Errorf("read %d bytes of the PNG, want the chunk headers and text only", read)
+	}
+}
+
+// pdfFixture writes PDF objects and the cross-reference sections that find them
+type pdfFixture struct {
+	bytes.Buffer
+	offsets map[int]int
+	pending []int
+}
+
+func newPDFFixture() *pdfFixture {
+	p := &pdfFixture{offsets: map[int]int{}, pending: []int{0}}
+	p.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	return p
+}
+
+func (p *pdfFixture) object(num int, body string) {
+	p.offsets[num] = p.Len()
+	p.pending = append(p.pending, num)
+	fmt.Fprintf(p, "%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+// xref writes a classic xref table of the objects since the last one
+func (p *pdfFixture) xref(trailer string) {
+	start := p.Len()
+	p.WriteString("xref\n")
+	nums := p.pending
+	sort.Ints(nums)
+	for i := 0; i < len(nums); {
+		j := i + 1
+		for j < len(nums) && nums[j] == nums[j-1]+1 {
+			j++
+		}
+		fmt.Fprintf(p, "%d %d\n", nums[i], j-i)
+		for _, num := range nums[i:j] {
+			if num == 0 {
+				p.WriteString("0000000000 65535 f\r\n")
+			} else {
+				fmt.Fprintf(p, "%010d 00000 n\r\n", p.offsets[num])
+			}
+		}
+		i = j
+	}
+	p.pending = nil
+	fmt.Fprintf(p, "trailer\n%s\nstartxref\n%d\n%%%%EOF\n", trailer, start)
+}
+
+func TestEmbeddedTimesPDF(t *testing.T) {
+	p := newPDFFixture()
+	p.object(1, "<< /Type /Catalog /Pages 3 0 R >>")
+	p.object(2, "<< /Producer (chronus) /CreationDate (D:20210308160634-07'00') >>")
+	p.object(3, "<< /Type /Pages /Kids [] /Count 0 >>")
+	// not the Info dictionary, so never read
+	p.object(4, "<< /Length 1048576 /CreationDate (D:19990101000000Z) >>\nstream\n"+string(make([]byte, 1<<20))+"\nendstream")
+	p.xref("<< /Size 5 /Root 1 0 R /Info 2 0 R >>")
+
+	// an incremental update replacing the Info dictionary with a UTF-16 ModDate
+	prev := bytes.LastIndex(p.Bytes(), []byte("xref"))
+	p.object(2, "<< /Producer (chronus) /CreationDate (D:20210308160634-07'00') /ModDate <FEFF0044003A00320030003200310030003300300039> >>")
+	p.xref(fmt.Sprintf("<< /Size 5 /Root 1 0 R /Info 2 0 R /Prev %d >>", prev))
+
+	got, read := embeddedTimes(t, p.Bytes())
+	wantTimes(t, got,
+		"PDF Info CreationDate=2021-03-08T16:06:34-07:00",
+		"PDF Info ModDate=2021-03-09T00:00:00Z",
+	)
+	if read > 1<<16 {
+		t.Errorf("read %d bytes of the PDF, want the xref, trailers, and Info only", read)
+	}
+
+	if _, err := EmbeddedTimes(bytes.NewReader([]byte("%PDF-1.4\n")), 9); err == nil {
+		t.Error("EmbeddedTimes() of a PDF without startxref did not fail")
+	}
+}
+
+func TestEmbeddedTimesPDFXrefStream(t *testing.T) {
+	p := newPDFFixture()
+	p.object(1, "<< /Type /Catalog >>")
+	// the Info dictionary compressed in object stream 3
+	info := "<< /CreationDate (D:20210308160634Z) >>"
+	p.object(3, fmt.Sprintf("<< /Type /ObjStm /N 1 /First 4 /Length %d >>\nstream\n2 0 %s\nendstream", len(info)+4, info))
+
+	// rows of type, offset or stream, and generation or index with the PNG Up predictor
+	xrefStart := p.Len()
+	rows := [][]byte{{0, 0, 0, 0}, {1, 0, byte(p.offsets[1]), 0}, {2, 0, 3, 0}, {1, 0, byte(p.offsets[3]), 0}, {1, byte(xrefStart >> 8), byte(xrefStart), 0}}
+	var raw []byte
+	prev := make([]byte, 4)
+	for _, row := range rows {
+		raw = append(raw, 2)
+		for i := range row {
+			raw = append(raw, row[i]-prev[i])
+		}
+		prev = row
+	}
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write(raw)
+	zw.Close()
+	fmt.Fprintf(p, "4 0 obj\n<< /Type /XRef /Size 5 /W [1 2 1] /Root 1 0 R /Info 2 0 R /Filter /FlateDecode /DecodeParms << /Columns 4 /Predictor 12 >> /Length %d >>\nstream\r\n", z.Len())
+	p.Write(z.Bytes())
+	fmt.Fprintf(p, "\r\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", xrefStart)
+
+	got, _ := embeddedTimes(t, p.Bytes())
+	wantTimes(t, got, "PDF Info CreationDate=2021-03-08T16:06:34Z")
+}
+
+func TestEmbeddedTimesArchives(t *testing.T) {
+	mtime := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, _ := zw.CreateHeader(&zip.FileHeader{Name: "a.txt", Modified: mtime})
+	w.Write([]byte("a"))
+	zw.Close()
+	got, _ := embeddedTimes(t, zipped.Bytes())
+	wantTimes(t, got, "ZIP a.txt=2021-03-08T16:06:34Z")
+
+	var tgz bytes.Buffer
+	gw := gzip.NewWriter(&tgz)
+	gw.Name, gw.ModTime = "a.tar", mtime.Add(time.Hour)
+	tw := tar.NewWriter(gw)
+	tw.WriteHeader(&tar.Header{Name: "a.txt", Mode: 0644, Size: 1, ModTime: mtime, Format: tar.FormatUSTAR})
+	tw.Write([]byte("a"))
+	tw.Close()
+	gw.Close()
+	got, _ = embeddedTimes(t, tgz.Bytes())
+	wantTimes(t, got, "gzip a.tar=2021-03-08T17:06:34Z", "tar a.txt=2021-03-08T16:06:34Z")
+
+	if _, err := EmbeddedTimes(bytes.NewReader([]byte("plain text")), 10); err == nil {
+		t.Error("EmbeddedTimes() of plain text did not fail")
+	}
+}
+
+func TestCaptureTime(t *testing.T) {
+	r := bytes.NewReader(exifFixture())
+	times, err := EmbeddedTimes(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	et, ok := CaptureTime(times)
+	if !ok || et.Field != "DateTimeOriginal" {
+		t.Errorf("CaptureTime() = %+v, %v, want DateTimeOriginal", et, ok)
+	}
+	if _, ok := CaptureTime(nil); ok {
+		t.Error("CaptureTime(nil) found a time")
+	}
+}
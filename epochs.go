@@ -0,0 +1,215 @@
+package chronus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// secondsFrom1601 is the number of seconds between 1601-01-01 (Windows, WebKit) and the UNIX Epoch
+	secondsFrom1601 = 11644473600
+
+	// secondsFromYear1 is the number of seconds between 0001-01-01 (.NET) and the UNIX Epoch
+	secondsFromYear1 = 62135596800
+
+	// secondsFrom2001 is the number of seconds between the UNIX Epoch and 2001-01-01 (Cocoa)
+	secondsFrom2001 = 978307200
+)
+
+// Epoch describes a timestamp system that counts from a fixed instant
+type Epoch struct {
+	Name        string // short name used on the command line (e.g. filetime)
+	Label       string // human readable name (e.g. Windows FILETIME)
+	Description string
+	Parse       func(s string) (time.Time, error)
+	Format      func(t time.Time) string
+}
+
+// Epochs lists the supported timestamp systems in display order
+var Epochs = []Epoch{
+	{
+		Name:        "unix",
+		Label:       "UNIX Timestamp",
+		Description: "seconds since 1970-01-01 UTC",
+		Parse:       ParseUnixDecimal,
+		Format:      func(t time.Time) string { return strconv.FormatInt(UnixTimestamp(t), 10) },
+	},
+	{
+		Name:        "unix-ms",
+		Label:       "UNIX Milliseconds",
+		Description: "milliseconds since 1970-01-01 UTC (JavaScript, Java)",
+		Parse:       func(s string) (time.Time, error) { return ParseUnixDecimalUnit(s, EpochMilliseconds) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(UnixMilli(t), 10) },
+	},
+	{
+		Name:        "unix-us",
+		Label:       "UNIX Microseconds",
+		Description: "microseconds since 1970-01-01 UTC",
+		Parse:       func(s string) (time.Time, error) { return ParseUnixDecimalUnit(s, EpochMicroseconds) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(UnixMicro(t), 10) },
+	},
+	{
+		Name:        "unix-ns",
+		Label:       "UNIX Nanoseconds",
+		Description: "nanoseconds since 1970-01-01 UTC",
+		Parse:       func(s string) (time.Time, error) { return ParseUnixDecimalUnit(s, EpochNanoseconds) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(UnixNano(t), 10) },
+	},
+	{
+		Name:        "filetime",
+		Label:       "Windows FILETIME",
+		Description: "100 nanosecond intervals since 1601-01-01 UTC",
+		Parse:       func(s string) (time.Time, error) { return parseEpochInt(s, FileTimeToTime) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(FileTime(t), 10) },
+	},
+	{
+		Name:        "ldap",
+		Label:       "LDAP/AD Timestamp",
+		Description: "Active Directory Integer8, the same as a Windows FILETIME",
+		Parse:       func(s string) (time.Time, error) { return parseEpochInt(s, LDAPTimestampToTime) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(LDAPTimestamp(t), 10) },
+	},
+	{
+		Name:        "dotnet",
+		Label:       ".NET DateTime Ticks",
+		Description: "100 nanosecond intervals since 0001-01-01 (DateTime.Ticks)",
+		Parse:       func(s string) (time.Time, error) { return parseEpochInt(s, DotNetTicksToTime) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(DotNetTicks(t), 10) },
+	},
+	{
+		Name:        "cocoa",
+		Label:       "Cocoa Timestamp",
+		Description: "seconds since 2001-01-01 UTC (Apple CFAbsoluteTime, NSDate)",
+		Parse:       ParseCocoaTimestamp,
+		Format:      func(t time.Time) string { return CocoaTimestampString(t, 6) },
+	},
+	{
+		Name:        "ntp",
+		Label:       "NTP Timestamp",
+		Description: "seconds since 1900-01-01 UTC with a 32 bit fraction (decimal, 0x hex, or ntpq hex)",
+		Parse:       ParseNTPTime,
+		Format:      func(t time.Time) string { return NTPTimestampFromTime(t).String() },
+	},
+	{
+		Name:        "snowflake",
+		Label:       "Snowflake ID",
+		Description: "Twitter/Discord style ID with milliseconds since SnowflakeEpoch in the 41 bits below the sign bit",
+		Parse:       ParseSnowflake,
+		Format:      func(t time.Time) string { return strconv.FormatInt(SnowflakeFromTime(t, SnowflakeEpoch), 10) },
+	},
+	{
+		Name:        "webkit",
+		Label:       "WebKit Timestamp",
+		Description: "microseconds since 1601-01-01 UTC (Chrome, WebKit)",
+		Parse:       func(s string) (time.Time, error) { return parseEpochInt(s, WebKitTimestampToTime) },
+		Format:      func(t time.Time) string { return strconv.FormatInt(WebKitTimestamp(t), 10) },
+	},
+}
+
+// GetEpoch returns the timestamp system with the given name
+func GetEpoch(name string) (epoch Epoch, err error) {
+	for _, epoch = range Epochs {
+		if strings.EqualFold(epoch.Name, name) {
+			return epoch, nil
+		}
+	}
+	return epoch, fmt.Errorf("unknown epoch %q (expected one of %s)", name, strings.Join(EpochNames(), ", "))
+}
+
+// EpochNames returns the names of the supported timestamp systems
+func EpochNames() (names []string) {
+	for _, epoch := range Epochs {
+		names = append(names, epoch.Name)
+	}
+	return names
+}
+
+// ParseEpoch converts a timestamp from the named timestamp system into Go time.Time
+func ParseEpoch(name, s string) (t time.Time, err error) {
+	epoch, err := GetEpoch(name)
+	if err != nil {
+		return t, err
+	}
+	return epoch.Parse(strings.TrimSpace(s))
+}
+
+// parseEpochInt parses a decimal or 0x prefixed hexadecimal integer timestamp
+func parseEpochInt(s string, toTime func(int64) time.Time) (t time.Time, err error) {
+	var i int64
+	if strings.HasPrefix(strings.ToLower(s), "0x") {
+		var u uint64
+		u, err = strconv.ParseUint(s[2:], 16, 64)
+		i = int64(u)
+	} else {
+		i, err = strconv.ParseInt(s, 10, 64)
+	}
+	if err != nil {
+		return t, err
+	}
+	return toTime(i), nil
+}
+
+// CocoaTimestamp converts Go time.Time into seconds since 2001-01-01 UTC (Apple CFAbsoluteTime)
+func CocoaTimestamp(t time.Time) float64 {
+	f, _ := strconv.ParseFloat(CocoaTimestampString(t, 9), 64)
+	return f
+}
+
+// CocoaTimestampString returns the exact decimal Cocoa timestamp with precision fractional digits
+func CocoaTimestampString(t time.Time, precision int) string {
+	return UnixDecimalString(t.Add(-secondsFrom2001*time.Second), precision)
+}
+
+// CocoaTimestampToTime converts seconds since 2001-01-01 UTC into Go time.Time
+func CocoaTimestampToTime(f float64) time.Time {
+	sec := int64(f)
+	return time.Unix(sec+secondsFrom2001, int64((f-float64(sec))*1e9))
+}
+
+// ParseCocoaTimestamp exactly converts a decimal Cocoa timestamp string into Go time.Time
+func ParseCocoaTimestamp(s string) (time.Time, error) {
+	t, err := ParseUnixDecimal(s)
+	return t.Add(secondsFrom2001 * time.Second), err
+}
+
+// DotNetTicks converts Go time.Time into .NET DateTime ticks (100 nanosecond intervals since 0001-01-01)
+func DotNetTicks(t time.Time) int64 {
+	return (t.Unix()+secondsFromYear1)*1e7 + int64(t.Nanosecond())/100
+}
+
+// DotNetTicksToTime converts .NET DateTime ticks into Go time.Time
+func DotNetTicksToTime(ticks int64) time.Time {
+	return time.Unix(ticks/1e7-secondsFromYear1, ticks%1e7*100)
+}
+
+// FileTime converts Go time.Time into a Windows FILETIME (100 nanosecond intervals since 1601-01-01 UTC)
+func FileTime(t time.Time) int64 {
+	return (t.Unix()+secondsFrom1601)*1e7 + int64(t.Nanosecond())/100
+}
+
+// FileTimeToTime converts a Windows FILETIME into Go time.Time
+func FileTimeToTime(ft int64) time.Time {
+	return time.Unix(ft/1e7-secondsFrom1601, ft%1e7*100)
+}
+
+// LDAPTimestamp converts Go time.Time into an Active Directory Integer8 timestamp (e.g. lastLogonTimestamp)
+func LDAPTimestamp(t time.Time) int64 {
+	return FileTime(t)
+}
+
+// LDAPTimestampToTime converts an Active Directory Integer8 timestamp into Go time.Time
+func LDAPTimestampToTime(i int64) time.Time {
+	return FileTimeToTime(i)
+}
+
+// WebKitTimestamp converts Go time.Time into a WebKit/Chrome timestamp (microseconds since 1601-01-01 UTC)
+func WebKitTimestamp(t time.Time) int64 {
+	return (t.Unix()+secondsFrom1601)*1e6 + int64(t.Nanosecond())/1e3
+}
+
+// WebKitTimestampToTime converts a WebKit/Chrome timestamp into Go time.Time
+func WebKitTimestampToTime(us int64) time.Time {
+	return time.Unix(us/1e6-secondsFrom1601, us%1e6*1e3)
+}
@@ -0,0 +1,62 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEpochRoundTrips(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 123456700, time.UTC)
+	tests := []struct {
+		name       string
+		unix       string // the UNIX Epoch in this system
+		want       string // ts in this system
+		resolution time.Duration
+	}{
+		{"filetime", "116444736000000000", "132596931941234567", 100},
+		{"ldap", "116444736000000000", "132596931941234567", 100},
+		{"dotnet", "621355968000000000", "637508163941234567", 100},
+		{"cocoa", "-978307200.000000", "636912394.123456", time.Microsecond},
+		{"webkit", "11644473600000000", "13259693194123456", time.Microsecond},
+	}
+	for _, tt := range tests {
+		epoch, err := GetEpoch(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := epoch.Format(time.Unix(0, 0)); got != tt.unix {
+			t.Errorf("%s UNIX Epoch = %s, want %s", tt.name, got, tt.unix)
+		}
+		got := epoch.Format(ts)
+		if got != tt.want {
+			t.Errorf("%s Format(%s) = %s, want %s", tt.name, ts.Format(time.RFC3339Nano), got, tt.want)
+		}
+		back, err := ParseEpoch(tt.name, got)
+		if err != nil {
+			t.Errorf("ParseEpoch(%s, %s) error: %s", tt.name, got, err)
+			continue
+		}
+		if want := ts.Truncate(tt.resolution); !back.Equal(want) {
+			t.Errorf("ParseEpoch(%s, %s) = %s, want %s", tt.name, got, back.UTC().Format(time.RFC3339Nano), want.Format(time.RFC3339Nano))
+		}
+	}
+}
+
+func TestCocoaTimestampToTime(t *testing.T) {
+	want := time.Date(2001, 1, 1, 0, 0, 1, 500000000, time.UTC)
+	if got := CocoaTimestampToTime(1.5); !got.Equal(want) {
+		t.Errorf("CocoaTimestampToTime(1.5) = %s, want %s", got.UTC(), want)
+	}
+	if got := CocoaTimestamp(want); got != 1.5 {
+		t.Errorf("CocoaTimestamp(%s) = %v, want 1.5", want, got)
+	}
+}
+
+func TestGetEpochUnknown(t *testing.T) {
+	if _, err := GetEpoch("mayan"); err == nil {
+		t.Error(`GetEpoch("mayan") did not fail`)
+	}
+	if _, err := ParseEpoch("filetime", "1.5"); err == nil {
+		t.Error(`ParseEpoch("filetime", "1.5") did not fail`)
+	}
+}
@@ -0,0 +1,275 @@
+package chronus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EXIFDateTime is the EXIF DateTime, DateTimeOriginal, and DateTimeDigitized tag format
+	EXIFDateTime = "2006:01:02 15:04:05" // 2021:03:08 16:06:34
+
+	regExEXIFDateTime   = `^(\d{4}:\d\d:\d\d \d\d:\d\d:\d\d)(\.(\d+))? ?([+-]\d\d:\d\d|Z)?$`
+	regExEXIFOffsetTime = `^([+-])(\d\d):(\d\d)$`
+)
+
+var (
+	reEXIFDateTime   = regexp.MustCompile(regExEXIFDateTime)
+	reEXIFOffsetTime = regexp.MustCompile(regExEXIFOffsetTime)
+)
+
+// GetEXIFFormat determines if the provided string is an EXIF date-time,
+// optionally followed by its SubSecTime as a fraction and its OffsetTime
+func GetEXIFFormat(dtz string) (format string) {
+	if reEXIFDateTime.MatchString(dtz) {
+		format = EXIFDateTime
+		DebugPrintf("chronus.GetEXIFFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// ParseEXIF parses an EXIF date-time such as "2021:03:08 16:06:34", which may
+// have a fraction and an offset appended such as "2021:03:08 16:06:34.5-07:00"
+func ParseEXIF(dtz string) (t time.Time, err error) {
+	m := reEXIFDateTime.FindStringSubmatch(strings.TrimSpace(dtz))
+	if m == nil {
+		return t, fmt.Errorf("invalid EXIF date-time %q", dtz)
+	}
+	offset := m[4]
+	if offset == "Z" {
+		offset = "+00:00"
+	}
+	return ParseEXIFDateTime(m[1], offset, m[3])
+}
+
+// ParseEXIFDateTime combines an EXIF DateTime* tag with its OffsetTime* and
+// SubSecTime* tags, either of which may be empty. Without an offset the time is
+// in DefaultLocation (nil is UTC). A date-time of blanks or zeros, which
+// EXIF uses for unknown, is an error.
+func ParseEXIFDateTime(dateTime, offsetTime, subSecTime string) (t time.Time, err error) {
+	dateTime = strings.TrimRight(dateTime, "\x00")
+	if strings.Trim(dateTime, " :0") == "" {
+		return t, fmt.Errorf("EXIF date-time %q is unknown", dateTime)
+	}
+
+	loc := defaultLocation()
+	offsetTime = strings.TrimSpace(strings.TrimRight(offsetTime, "\x00"))
+	if len(offsetTime) > 0 {
+		m := reEXIFOffsetTime.FindStringSubmatch(offsetTime)
+		if m == nil {
+			return t, fmt.Errorf("invalid EXIF OffsetTime %q", offsetTime)
+		}
+		hh, _ := strconv.Atoi(m[2])
+		mm, _ := strconv.Atoi(m[3])
+		offset := hh*3600 + mm*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	if t, err = time.ParseInLocation(EXIFDateTime, dateTime, loc); err != nil {
+		return t, fmt.Errorf("invalid EXIF date-time %q: %s", dateTime, err.Error())
+	}
+
+	subSecTime = strings.TrimSpace(strings.TrimRight(subSecTime, "\x00"))
+	if len(subSecTime) > 0 {
+		if strings.Trim(subSecTime, "0123456789") != "" {
+			return t, fmt.Errorf("invalid EXIF SubSecTime %q", subSecTime)
+		}
+		if len(subSecTime) > 9 {
+			subSecTime = subSecTime[:9]
+		}
+		ns, _ := strconv.Atoi(subSecTime + strings.Repeat("0", 9-len(subSecTime)))
+		t = t.Add(time.Duration(ns))
+	}
+
+	return t, nil
+}
+
+// FormatEXIF returns t as the EXIF DateTime, OffsetTime, and SubSecTime tag
+// values such as "2021:03:08 16:06:34", "-07:00", and "5" (empty without a fraction)
+func FormatEXIF(t time.Time) (dateTime, offsetTime, subSecTime string) {
+	dateTime = t.Format(EXIFDateTime)
+	offsetTime = t.Format("-07:00")
+	if t.Nanosecond() > 0 {
+		subSecTime = strings.TrimRight(fmt.Sprintf("%09d", t.Nanosecond()), "0")
+	}
+
+	return dateTime, offsetTime, subSecTime
+}
+
+// exifDateTags are the EXIF date-time tags with their OffsetTime and SubSecTime tags
+var exifDateTags = []struct {
+	name                string
+	tag, offset, subSec uint16
+}{
+	{"DateTime", 0x0132, 0x9010, 0x9290},
+	{"DateTimeOriginal", 0x9003, 0x9011, 0x9291},
+	{"DateTimeDigitized", 0x9004, 0x9012, 0x9292},
+}
+
+// tiffEntry is a TIFF IFD entry with the position of its value
+type tiffEntry struct {
+	typ    uint16
+	count  uint32
+	offset int64 // of the value, which is in the entry itself when it fits in 4 bytes
+	size   int64
+}
+
+// tiffTypeSizes are the byte sizes of the TIFF field types
+var tiffTypeSizes = map[uint16]uint64{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+// maxTIFFValue bounds the values read, which for the tags used are a few bytes
+const maxTIFFValue = 1 << 16
+
+// tiffReader reads the IFDs and tag values of TIFF data in place, where offsets
+// are from the start of the TIFF header (a TIFF file or the payload of a JPEG
+// APP1 Exif segment or a PNG eXIf chunk)
+type tiffReader struct {
+	r     io.ReaderAt
+	size  int64
+	order binary.ByteOrder
+}
+
+// ifd reads the entries of the IFD at offset, skipping any that are out of bounds
+func (tr tiffReader) ifd(offset uint32) map[uint16]tiffEntry {
+	entries := map[uint16]tiffEntry{}
+	if offset == 0 || int64(offset)+2 > tr.size {
+		return entries
+	}
+	var count [2]byte
+	if _, err := tr.r.ReadAt(count[:], int64(offset)); err != nil {
+		return entries
+	}
+	n := int64(tr.order.Uint16(count[:]))
+	if fit := (tr.size - int64(offset) - 2) / 12; n > fit {
+		n = fit
+	}
+	data := make([]byte, n*12)
+	if _, err := tr.r.ReadAt(data, int64(offset)+2); err != nil {
+		return entries
+	}
+
+	for pos := 0; pos+12 <= len(data); pos += 12 {
+		e := tiffEntry{
+			typ:    tr.order.Uint16(data[pos+2:]),
+			count:  tr.order.Uint32(data[pos+4:]),
+			offset: int64(offset) + 2 + int64(pos) + 8,
+		}
+		e.size = int64(tiffTypeSizes[e.typ] * uint64(e.count))
+		if e.size > 4 {
+			e.offset = int64(tr.order.Uint32(data[pos+8:]))
+		}
+		if e.size == 0 || e.offset+e.size > tr.size {
+			continue
+		}
+		entries[tr.order.Uint16(data[pos:])] = e
+	}
+
+	return entries
+}
+
+// value reads the value of an entry, nil when it is missing or too large
+func (tr tiffReader) value(e tiffEntry) []byte {
+	if e.size == 0 || e.size > maxTIFFValue {
+		return nil
+	}
+	b := make([]byte, e.size)
+	if _, err := tr.r.ReadAt(b, e.offset); err != nil {
+		return nil
+	}
+	return b
+}
+
+// ascii returns the text of an ASCII entry up to its NUL
+func (tr tiffReader) ascii(e tiffEntry) string {
+	if e.typ != 2 {
+		return ""
+	}
+	s := string(tr.value(e))
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+// long returns the first value of a LONG or SHORT entry
+func (tr tiffReader) long(e tiffEntry) uint32 {
+	b := tr.value(e)
+	switch {
+	case e.typ == 3 && len(b) >= 2:
+		return uint32(tr.order.Uint16(b))
+	case e.typ == 4 && len(b) >= 4:
+		return tr.order.Uint32(b)
+	}
+	return 0
+}
+
+// exifTimes reads the EXIF and GPS date-times from the TIFF data in r
+func exifTimes(r io.ReaderAt, size int64) (times []EmbeddedTime, err error) {
+	header := make([]byte, 8)
+	if size < 8 {
+		return nil, fmt.Errorf("TIFF header too short")
+	}
+	if _, err = r.ReadAt(header, 0); err != nil {
+		return nil, err
+	}
+	tr := tiffReader{r: r, size: size}
+	switch string(header[:4]) {
+	case "II*\x00":
+		tr.order = binary.LittleEndian
+	case "MM\x00*":
+		tr.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+
+	ifd0 := tr.ifd(tr.order.Uint32(header[4:]))
+	exif := tr.ifd(tr.long(ifd0[0x8769]))
+	for _, tag := range exifDateTags {
+		entries := exif
+		if _, ok := ifd0[tag.tag]; ok {
+			entries = ifd0
+		}
+		raw := tr.ascii(entries[tag.tag])
+		if len(raw) == 0 {
+			continue
+		}
+		t, err := ParseEXIFDateTime(raw, tr.ascii(exif[tag.offset]), tr.ascii(exif[tag.subSec]))
+		if err != nil {
+			DebugPrintf("chronus.exifTimes() | %s: %s\n", tag.name, err.Error())
+			continue
+		}
+		times = append(times, EmbeddedTime{Source: "EXIF", Field: tag.name, Raw: raw, Time: t})
+	}
+
+	// GPSDateStamp and GPSTimeStamp are UTC
+	gps := tr.ifd(tr.long(ifd0[0x8825]))
+	date := tr.ascii(gps[0x001D])
+	clock := gps[0x0007]
+	if len(date) > 0 && clock.typ == 5 && clock.count == 3 {
+		t, err := time.Parse("2006:01:02", date)
+		value := tr.value(clock)
+		if err == nil && len(value) == 24 {
+			for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+				num := tr.order.Uint32(value[i*8:])
+				den := tr.order.Uint32(value[i*8+4:])
+				if den > 0 {
+					t = t.Add(time.Duration(math.Round(float64(num) / float64(den) * float64(unit))))
+				}
+			}
+			raw := fmt.Sprintf("%s %s", date, t.Format("15:04:05.999999999"))
+			times = append(times, EmbeddedTime{Source: "EXIF", Field: "GPSDateStamp GPSTimeStamp", Raw: raw, Time: t})
+		}
+	}
+
+	return times, nil
+}
@@ -0,0 +1,51 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEXIF(t *testing.T) {
+	defer func(loc *time.Location) { DefaultLocation = loc }(DefaultLocation)
+	DefaultLocation = time.UTC
+
+	tests := []struct {
+		dtz  string
+		want string
+	}{
+		{"2021:03:08 16:06:34", "2021-03-08T16:06:34Z"},
+		{"2021:03:08 16:06:34.5-07:00", "2021-03-08T16:06:34.5-07:00"},
+		{"2021:03:08 16:06:34 +05:30", "2021-03-08T16:06:34+05:30"},
+		{"2021:03:08 16:06:34Z", "2021-03-08T16:06:34Z"},
+	}
+	for _, tt := range tests {
+		if got := GetEXIFFormat(tt.dtz); got != EXIFDateTime {
+			t.Errorf("GetEXIFFormat(%q) = %q, want %q", tt.dtz, got, EXIFDateTime)
+		}
+		got, err := ParseEXIF(tt.dtz)
+		if err != nil {
+			t.Errorf("ParseEXIF(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseEXIF(%q) = %s, want %s", tt.dtz, s, tt.want)
+		}
+	}
+
+	for _, dtz := range []string{"0000:00:00 00:00:00", "    :  :     :  :  ", "2021:13:08 16:06:34"} {
+		if _, err := ParseEXIF(dtz); err == nil {
+			t.Errorf("ParseEXIF(%q) should fail", dtz)
+		}
+	}
+}
+
+func TestFormatEXIF(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 500000000, time.FixedZone("", -7*3600))
+	dateTime, offsetTime, subSecTime := FormatEXIF(ts)
+	if dateTime != "2021:03:08 16:06:34" || offsetTime != "-07:00" || subSecTime != "5" {
+		t.Errorf("FormatEXIF = %q, %q, %q", dateTime, offsetTime, subSecTime)
+	}
+	if got, err := Format(ts, "EXIFDateTime"); err != nil || got != "2021:03:08 16:06:34" {
+		t.Errorf("Format(EXIFDateTime) = %q, %v", got, err)
+	}
+}
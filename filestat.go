@@ -0,0 +1,22 @@
+package chronus
+
+import "time"
+
+// FileStat holds the filesystem timestamps of a path. Times the platform or
+// filesystem does not record are zero.
+type FileStat struct {
+	Path   string
+	Access time.Time // atime, last read
+	Modify time.Time // mtime, last change to the contents
+	Change time.Time // ctime, last change to the inode (metadata)
+	Birth  time.Time // btime, creation
+}
+
+// StatFile returns the access, modification, change, and birth times of path
+// (following symbolic links) with the precision the filesystem provides. On
+// Linux the birth time comes from statx(2) when the kernel supports it.
+func StatFile(path string) (fs FileStat, err error) {
+	fs.Path = path
+	err = statTimes(path, &fs)
+	return fs, err
+}
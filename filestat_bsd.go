@@ -0,0 +1,24 @@
+//go:build darwin || freebsd || netbsd
+// +build darwin freebsd netbsd
+
+package chronus
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// statTimes reads the BSD stat(2) times which include the birth time
+func statTimes(path string, fs *FileStat) error {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return &os.PathError{Op: "stat", Path: path, Err: err}
+	}
+	fs.Access = time.Unix(st.Atimespec.Unix())
+	fs.Modify = time.Unix(st.Mtimespec.Unix())
+	fs.Change = time.Unix(st.Ctimespec.Unix())
+	fs.Birth = time.Unix(st.Birthtimespec.Unix())
+
+	return nil
+}
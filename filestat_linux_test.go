@@ -0,0 +1,67 @@
+package chronus
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func testFile(t *testing.T) (path string, atime, mtime time.Time) {
+	path = filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	atime = time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC)
+	mtime = time.Date(2021, 3, 8, 16, 6, 34, 123456789, time.UTC)
+	if err := os.Chtimes(path, atime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path, atime, mtime
+}
+
+func TestStatx(t *testing.T) {
+	if sysStatx == 0 {
+		t.Skip("the statx(2) number is not known for this architecture")
+	}
+	path, atime, mtime := testFile(t)
+
+	var fs FileStat
+	err := statx(path, &fs)
+	if err == syscall.ENOSYS || err == syscall.EPERM {
+		t.Skipf("statx(2) is not available: %s", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a wrong call number or struct layout would not give back the times just set
+	if !fs.Access.Equal(atime) || !fs.Modify.Equal(mtime) || fs.Change.IsZero() {
+		t.Errorf("statx() = %+v, want atime %s and mtime %s", fs, atime, mtime)
+	}
+}
+
+func TestStatFileFallback(t *testing.T) {
+	defer func(call func(string, *FileStat) error) { statxCall = call }(statxCall)
+	path, atime, mtime := testFile(t)
+
+	for _, errno := range []syscall.Errno{syscall.ENOSYS, syscall.EPERM} {
+		statxCall = func(string, *FileStat) error { return errno }
+		fs, err := StatFile(path)
+		if err != nil {
+			t.Errorf("StatFile() with statx %s error: %s", errno, err)
+			continue
+		}
+		if !fs.Access.Equal(atime) || !fs.Modify.Equal(mtime) || fs.Change.IsZero() || !fs.Birth.IsZero() {
+			t.Errorf("StatFile() with statx %s = %+v, want stat(2) times without a birth time", errno, fs)
+		}
+	}
+
+	// other errors are reported rather than hidden by the fallback
+	statxCall = func(string, *FileStat) error { return syscall.EACCES }
+	if sysStatx != 0 {
+		if _, err := StatFile(path); err == nil {
+			t.Error("StatFile() with statx EACCES did not fail")
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+// +build !linux,!darwin,!freebsd,!netbsd,!windows
+
+package chronus
+
+import "os"
+
+// statTimes only has the modification time where the platform's stat is not supported
+func statTimes(path string, fs *FileStat) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	fs.Modify = info.ModTime()
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package chronus
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// statTimes reads the NTFS creation, last access, and last write times. Windows
+// does not expose an inode change time through this API.
+func statTimes(path string, fs *FileStat) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	fs.Modify = info.ModTime()
+	if d, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		fs.Access = time.Unix(0, d.LastAccessTime.Nanoseconds())
+		fs.Birth = time.Unix(0, d.CreationTime.Nanoseconds())
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package chronus
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	namedFormats   = map[string]namedFormat{}
+	namedFormatsMu sync.RWMutex
+)
+
+type namedFormat struct {
+	name   string
+	layout string
+}
+
+func init() {
+	RegisterFormat("ANSIC", ANSIC)
+	RegisterFormat("CommonLogFormat", CommonLogFormat)
+	RegisterFormat("CommonLogTime", CommonLogTime)
+	RegisterFormat("ELBTimestamp", ELBTimestamp)
+	RegisterFormat("EXIFDateTime", EXIFDateTime)
+	RegisterFormat("GitDateTime", GitDateTime)
+	RegisterFormat("ISO8601", ISO8601)
+	RegisterFormat("ISO8601Z", ISO8601Z)
+	RegisterFormat("ISO8601alt", ISO8601alt)
+	RegisterFormat("ISO8601file1", ISO8601file1)
+	RegisterFormat("ISO8601file1Seconds", ISO8601file1Seconds)
+	RegisterFormat("ISO8601file2", ISO8601file2)
+	RegisterFormat("ISO8601file2Seconds", ISO8601file2Seconds)
+	RegisterFormat("Kitchen", Kitchen)
+	RegisterFormat("RFC822", RFC822)
+	RegisterFormat("RFC822Z", RFC822Z)
+	RegisterFormat("RFC850", RFC850)
+	RegisterFormat("RFC1123", RFC1123)
+	RegisterFormat("RFC1123Z", RFC1123Z)
+	RegisterFormat("RFC3339", RFC3339)
+	RegisterFormat("RFC3339Nano", RFC3339Nano)
+	RegisterFormat("RFC5322A", RFC5322A)
+	RegisterFormat("RFC5322B", RFC5322B)
+	RegisterFormat("RFC5322C", RFC5322C)
+	RegisterFormat("RubyDateTime", RubyDateTime)
+	RegisterFormat("SQLDateTime", SQLDateTime)
+	RegisterFormat("SQLDateTimeWithTZ", SQLDateTimeWithTZ)
+	RegisterFormat("SQLDateYearToDay", SQLDateYearToDay)
+	RegisterFormat("SQLDateYearToMonth", SQLDateYearToMonth)
+	RegisterFormat("SQLDateTimeYearToMinute", SQLDateTimeYearToMinute)
+	RegisterFormat("SQLDateTimeYearToSecondWithOffset", SQLDateTimeYearToSecondWithOffset)
+	RegisterFormat("Stamp", Stamp)
+	RegisterFormat("StampMilli", StampMilli)
+	RegisterFormat("StampMicro", StampMicro)
+	RegisterFormat("StampNano", StampNano)
+	RegisterFormat("UKCommon", UKCommon)
+	RegisterFormat("UKSlashDate", UKSlashDate)
+	RegisterFormat("UnixDateTime", UnixDateTime)
+	RegisterFormat("USCommonDateTime", USCommonDateTime)
+	RegisterFormat("USSlashDate", USSlashDate)
+}
+
+// RegisterFormat adds (or replaces) a named format. The format may be a Go
+// layout, strftime format, LDML pattern, or template. Names are case insensitive.
+func RegisterFormat(name, format string) {
+	namedFormatsMu.Lock()
+	defer namedFormatsMu.Unlock()
+	namedFormats[strings.ToLower(name)] = namedFormat{name: name, layout: format}
+}
+
+// LookupFormat returns the format registered under name
+func LookupFormat(name string) (format string, ok bool) {
+	namedFormatsMu.RLock()
+	defer namedFormatsMu.RUnlock()
+	nf, ok := namedFormats[strings.ToLower(name)]
+	return nf.layout, ok
+}
+
+// FormatNames returns the names of all registered formats in sorted order
+func FormatNames() (names []string) {
+	namedFormatsMu.RLock()
+	defer namedFormatsMu.RUnlock()
+	for _, nf := range namedFormats {
+		names = append(names, nf.name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names
+}
@@ -0,0 +1,43 @@
+package chronus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatRegistry(t *testing.T) {
+	defer func() {
+		namedFormatsMu.Lock()
+		delete(namedFormats, "chronustest")
+		namedFormatsMu.Unlock()
+	}()
+
+	if layout, ok := LookupFormat("rfc3339"); !ok || layout != RFC3339 {
+		t.Errorf("LookupFormat(rfc3339) = %q, %t, want %q", layout, ok, RFC3339)
+	}
+	if _, ok := LookupFormat("ChronusTest"); ok {
+		t.Errorf("LookupFormat(ChronusTest) found an unregistered format")
+	}
+
+	RegisterFormat("ChronusTest", "%Y/%j")
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+	if got, err := Format(ts, "chronustest"); err != nil || got != "2021/067" {
+		t.Errorf("Format(chronustest) = %q, %v, want 2021/067", got, err)
+	}
+	if got, err := ParseWithFormat("2021/067", "CHRONUSTEST"); err != nil || !got.Equal(ts.Truncate(24*time.Hour)) {
+		t.Errorf("ParseWithFormat(CHRONUSTEST) = %s, %v", got, err)
+	}
+
+	names := FormatNames()
+	found := false
+	for i, name := range names {
+		found = found || name == "ChronusTest"
+		if i > 0 && strings.ToLower(names[i-1]) > strings.ToLower(name) {
+			t.Errorf("FormatNames not sorted at %q, %q", names[i-1], name)
+		}
+	}
+	if !found {
+		t.Errorf("FormatNames() is missing ChronusTest")
+	}
+}
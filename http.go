@@ -0,0 +1,161 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// IMFFixdate is the preferred HTTP-date format from RFC 9110 (always GMT)
+	IMFFixdate = "Mon, 02 Jan 2006 15:04:05 GMT" // Sun, 06 Nov 1994 08:49:37 GMT
+
+	// RFC850Date is the obsolete RFC 850 HTTP-date format with a two digit year
+	RFC850Date = "Monday, 02-Jan-06 15:04:05 GMT" // Sunday, 06-Nov-94 08:49:37 GMT
+
+	// AsctimeDate is the obsolete ANSI C asctime() HTTP-date format
+	AsctimeDate = "Mon Jan _2 15:04:05 2006" // Sun Nov  6 08:49:37 1994
+
+	// CookieDate is to denote the format was read with the RFC 6265 cookie date algorithm
+	CookieDate = "Cookie Date (RFC 6265)"
+
+	regExIMFFixdate  = `^(Mon|Tue|Wed|Thu|Fri|Sat|Sun), \d\d (Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) \d{4} \d\d:\d\d:\d\d GMT$`
+	regExRFC850Date  = `^(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday), \d\d-(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)-(\d\d) \d\d:\d\d:\d\d GMT$`
+	regExAsctimeDate = `^(Mon|Tue|Wed|Thu|Fri|Sat|Sun) (Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) ( \d|\d\d) \d\d:\d\d:\d\d \d{4}$`
+)
+
+var (
+	reIMFFixdate  = regexp.MustCompile(regExIMFFixdate)
+	reRFC850Date  = regexp.MustCompile(regExRFC850Date)
+	reAsctimeDate = regexp.MustCompile(regExAsctimeDate)
+
+	reCookieTime       = regexp.MustCompile(`^(\d{1,2}):(\d{1,2}):(\d{1,2})(\D.*)?$`)
+	reCookieDayOfMonth = regexp.MustCompile(`^(\d{1,2})(\D.*)?$`)
+	reCookieYear       = regexp.MustCompile(`^(\d{2,4})(\D.*)?$`)
+)
+
+// GetHTTPDateFormat determines which of the RFC 9110 HTTP-date formats, if any, the provided string is
+func GetHTTPDateFormat(dtz string) (format string) {
+	switch {
+	case reIMFFixdate.MatchString(dtz):
+		format = IMFFixdate
+	case reRFC850Date.MatchString(dtz):
+		format = RFC850Date
+	case reAsctimeDate.MatchString(dtz):
+		format = AsctimeDate
+	}
+	if len(format) > 0 {
+		DebugPrintf("chronus.GetHTTPDateFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// FormatHTTPDate returns t as an IMF-fixdate in GMT such as "Sun, 06 Nov 1994 08:49:37 GMT"
+func FormatHTTPDate(t time.Time) string {
+	return t.UTC().Format(IMFFixdate)
+}
+
+// ParseHTTPDate parses an IMF-fixdate, RFC 850, or asctime HTTP-date per RFC 9110.
+// A two digit RFC 850 year that would be more than 50 years in the future is
+// taken as the most recent past year with the same last two digits.
+func ParseHTTPDate(dtz string) (t time.Time, err error) {
+	return parseHTTPDate(dtz, time.Now())
+}
+
+func parseHTTPDate(dtz string, now time.Time) (t time.Time, err error) {
+	switch GetHTTPDateFormat(dtz) {
+	case IMFFixdate:
+		return time.Parse(IMFFixdate, dtz)
+	case AsctimeDate:
+		return time.Parse(AsctimeDate, dtz)
+	case RFC850Date:
+		t, err = time.Parse(RFC850Date, dtz)
+		if err != nil {
+			return t, err
+		}
+		yy, _ := strconv.Atoi(reRFC850Date.FindStringSubmatch(dtz)[3])
+		year := now.UTC().Year()/100*100 + yy
+		if year > now.UTC().Year()+50 {
+			year -= 100
+		}
+		return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+	}
+
+	return t, fmt.Errorf("invalid HTTP-date %q", dtz)
+}
+
+// isCookieDelimiter reports whether c is a delimiter in the RFC 6265 cookie date grammar
+func isCookieDelimiter(c rune) bool {
+	return c == 0x09 || (c >= 0x20 && c <= 0x2f) || (c >= 0x3b && c <= 0x40) ||
+		(c >= 0x5b && c <= 0x60) || (c >= 0x7b && c <= 0x7e)
+}
+
+// ParseCookieDate parses a date with the lenient algorithm browsers use for
+// cookie Expires attributes (RFC 6265 section 5.1.1). The result is in UTC.
+func ParseCookieDate(s string) (t time.Time, err error) {
+	var (
+		foundTime, foundDay, foundMonth, foundYear bool
+		hour, minute, second, day, year            int
+		month                                      time.Month
+	)
+
+	for _, token := range strings.FieldsFunc(s, isCookieDelimiter) {
+		if !foundTime {
+			if m := reCookieTime.FindStringSubmatch(token); m != nil {
+				hour, _ = strconv.Atoi(m[1])
+				minute, _ = strconv.Atoi(m[2])
+				second, _ = strconv.Atoi(m[3])
+				foundTime = true
+				continue
+			}
+		}
+		if !foundDay {
+			if m := reCookieDayOfMonth.FindStringSubmatch(token); m != nil {
+				day, _ = strconv.Atoi(m[1])
+				foundDay = true
+				continue
+			}
+		}
+		if !foundMonth && len(token) >= 3 {
+			for i, abbr := range englishMonthsAbbr {
+				if strings.EqualFold(token[:3], abbr) {
+					month = time.Month(i + 1)
+					foundMonth = true
+					break
+				}
+			}
+			if foundMonth {
+				continue
+			}
+		}
+		if !foundYear {
+			if m := reCookieYear.FindStringSubmatch(token); m != nil {
+				year, _ = strconv.Atoi(m[1])
+				foundYear = true
+				continue
+			}
+		}
+	}
+
+	if year >= 70 && year <= 99 {
+		year += 1900
+	} else if year >= 0 && year <= 69 {
+		year += 2000
+	}
+
+	switch {
+	case !foundTime || !foundDay || !foundMonth || !foundYear:
+		return t, fmt.Errorf("invalid cookie date %q: missing time, day, month, or year", s)
+	case day < 1 || day > 31 || year < 1601 || hour > 23 || minute > 59 || second > 59:
+		return t, fmt.Errorf("invalid cookie date %q: value out of range", s)
+	}
+
+	t = time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid cookie date %q: day %d is not in %s", s, day, month)
+	}
+	return t, nil
+}
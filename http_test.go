@@ -0,0 +1,76 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHTTPDate(t *testing.T) {
+	// the three forms of the RFC 9110 example
+	want := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+	now := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+	inputs := map[string]string{
+		"Sun, 06 Nov 1994 08:49:37 GMT":  IMFFixdate,
+		"Sunday, 06-Nov-94 08:49:37 GMT": RFC850Date,
+		"Sun Nov  6 08:49:37 1994":       AsctimeDate,
+	}
+	for input, format := range inputs {
+		if got := GetHTTPDateFormat(input); got != format {
+			t.Errorf("GetHTTPDateFormat(%q) = %q, want %q", input, got, format)
+		}
+		got, err := parseHTTPDate(input, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseHTTPDate(%q) = %s, %v, want %s", input, got, err, want)
+		}
+		p, err := ParseDetailed(input)
+		if err != nil || !p.Time.Equal(want) || p.Format != format {
+			t.Errorf("ParseDetailed(%q) = %s as %q, %v", input, p.Time, p.Format, err)
+		}
+	}
+
+	// RFC 850 years more than 50 years ahead belong to the previous century
+	for input, year := range map[string]int{
+		"Monday, 08-Mar-71 16:06:34 GMT":   2071,
+		"Thursday, 08-Mar-72 16:06:34 GMT": 1972,
+	} {
+		got, err := parseHTTPDate(input, now)
+		if err != nil || got.Year() != year {
+			t.Errorf("parseHTTPDate(%q) = %s, %v, want year %d", input, got, err, year)
+		}
+	}
+
+	if got := FormatHTTPDate(want.In(time.FixedZone("PST", -8*3600))); got != "Sun, 06 Nov 1994 08:49:37 GMT" {
+		t.Errorf("FormatHTTPDate() = %q", got)
+	}
+	if _, err := ParseHTTPDate("Sun, 06 Nov 1994 08:49:37 PST"); err == nil {
+		t.Error("ParseHTTPDate with a zone other than GMT did not fail")
+	}
+}
+
+func TestParseCookieDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Wed, 21 Oct 2015 07:28:00 GMT", "2015-10-21T07:28:00Z"},
+		{"Wed, 21-Oct-15 07:28:00 GMT", "2015-10-21T07:28:00Z"},
+		{"Thu, 01-Jan-70 00:00:01 GMT", "1970-01-01T00:00:01Z"},
+		{"21 october 2015 7:28:0", "2015-10-21T07:28:00Z"},
+		{"Tuesday, 08-Mar-2044 16:06:34", "2044-03-08T16:06:34Z"},
+		{"Wed, 21 Oct 2015", ""},
+		{"Wed, 31 Feb 2015 07:28:00 GMT", ""},
+		{"Wed, 21 Oct 1600 07:28:00 GMT", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseCookieDate(tt.input)
+		if len(tt.want) == 0 {
+			if err == nil {
+				t.Errorf("ParseCookieDate(%q) = %s, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || got.Format(time.RFC3339) != tt.want {
+			t.Errorf("ParseCookieDate(%q) = %s, %v, want %s", tt.input, got.Format(time.RFC3339), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package chronus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// UUIDv1Format is to denote the format is a time based UUID such as "6e8bc430-9c3a-11d9-9669-0800200c9a66"
+	UUIDv1Format = "UUID v1"
+
+	// UUIDv6Format is to denote the format is a reordered time based UUID such as "1ec9414c-232a-6b00-b3c8-9f6bdeced846"
+	UUIDv6Format = "UUID v6"
+
+	// UUIDv7Format is to denote the format is a UNIX time based UUID such as "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+	UUIDv7Format = "UUID v7"
+
+	// ULIDFormat is to denote the format is a ULID such as "01F0B6HW5B8ZJ5Z9VQ2T5N6Y3K"
+	ULIDFormat = "ULID"
+
+	// KSUIDFormat is to denote the format is a Segment KSUID such as "0ujtsYcgvSTl8PAuAdqWYSMnLOv"
+	KSUIDFormat = "KSUID"
+
+	// ObjectIDFormat is to denote the format is a MongoDB ObjectID such as "60464d0a0000000000000000"
+	ObjectIDFormat = "MongoDB ObjectID"
+
+	// SnowflakeFormat is to denote the format is a Twitter/Discord style Snowflake ID
+	SnowflakeFormat = "Snowflake ID"
+
+	// uuidEpochOffset is the number of seconds between the UUID epoch (1582-10-15) and the UNIX Epoch
+	uuidEpochOffset = 12219292800
+
+	// ksuidEpoch is the UNIX timestamp of the KSUID epoch (2014-05-13 16:53:20 UTC)
+	ksuidEpoch = 1400000000
+
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62          = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// ksuidMax is the largest KSUID (2^160 - 1). The base62 digits sort in ASCII
+	// order so a 27 character KSUID can be compared to it as a string.
+	ksuidMax = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+	// regExUUID requires the dashed 8-4-4-4-12 form or a urn:uuid: or braced UUID
+	// so that hex digests such as MD5 sums are not detected as UUIDs
+	regExUUID     = `^(?i)((urn:uuid:|\{)?[0-9a-f]{8}(-[0-9a-f]{4}){3}-[0-9a-f]{12}|(urn:uuid:|\{)[0-9a-f]{32})\}?$`
+	regExUUIDHex  = `^(?i)(urn:uuid:|\{)?([0-9a-f]{8})-?([0-9a-f]{4})-?([0-9a-f]{4})-?([0-9a-f]{4})-?([0-9a-f]{12})\}?$`
+	regExULID     = `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`
+	regExKSUID    = `^[0-9A-Za-z]{27}$`
+	regExObjectID = `^(?i)(ObjectId\(["']?)?([0-9a-f]{24})(["']?\))?$`
+)
+
+var (
+	// TwitterSnowflakeEpoch is the Twitter Snowflake epoch (1288834974657 ms)
+	TwitterSnowflakeEpoch = time.Unix(1288834974, 657000000).UTC()
+
+	// DiscordSnowflakeEpoch is the Discord Snowflake epoch (1420070400000 ms)
+	DiscordSnowflakeEpoch = time.Unix(1420070400, 0).UTC()
+
+	// SnowflakeEpoch is the epoch used to decode Snowflake IDs
+	SnowflakeEpoch = TwitterSnowflakeEpoch
+
+	reUUID     = regexp.MustCompile(regExUUID)
+	reUUIDHex  = regexp.MustCompile(regExUUIDHex)
+	reULID     = regexp.MustCompile(regExULID)
+	reKSUID    = regexp.MustCompile(regExKSUID)
+	reObjectID = regexp.MustCompile(regExObjectID)
+)
+
+func init() {
+	if name := os.Getenv("CHRONUS_SNOWFLAKE_EPOCH"); len(name) > 0 {
+		if epoch, err := ParseSnowflakeEpoch(name); err == nil {
+			SnowflakeEpoch = epoch
+		}
+	}
+}
+
+// GetIDFormat determines which time ordered ID, if any, the provided string is
+func GetIDFormat(id string) (format string) {
+	id = strings.TrimSpace(id)
+	switch {
+	case reUUID.MatchString(id):
+		b, _ := uuidBytes(id)
+		switch b[6] >> 4 {
+		case 1:
+			format = UUIDv1Format
+		case 6:
+			format = UUIDv6Format
+		case 7:
+			format = UUIDv7Format
+		}
+	case reObjectID.MatchString(id):
+		format = ObjectIDFormat
+	case reULID.MatchString(id):
+		format = ULIDFormat
+	case isKSUID(id):
+		format = KSUIDFormat
+	}
+	if len(format) > 0 {
+		DebugPrintf("chronus.GetIDFormat() | id: %q | format: %q\n", id, format)
+	}
+
+	return format
+}
+
+// ParseID decodes the timestamp embedded in a UUID v1/v6/v7, ULID, KSUID, or
+// MongoDB ObjectID and reports which kind of ID it is. Snowflake IDs are plain
+// integers and must be decoded with ParseSnowflake.
+func ParseID(id string) (t time.Time, format string, err error) {
+	id = strings.TrimSpace(id)
+	format = GetIDFormat(id)
+	switch format {
+	case UUIDv1Format, UUIDv6Format, UUIDv7Format:
+		t, err = UUIDToTime(id)
+	case ULIDFormat:
+		t, err = ULIDToTime(id)
+	case KSUIDFormat:
+		t, err = KSUIDToTime(id)
+	case ObjectIDFormat:
+		t, err = ObjectIDToTime(id)
+	default:
+		if reUUID.MatchString(id) {
+			b, _ := uuidBytes(id)
+			return t, format, fmt.Errorf("UUID version %d does not contain a timestamp", b[6]>>4)
+		}
+		err = fmt.Errorf("unrecognized ID %q", id)
+	}
+
+	return t, format, err
+}
+
+// uuidBytes decodes the 16 bytes of a UUID in its canonical, braced, urn:uuid:, or undashed form
+func uuidBytes(id string) (b [16]byte, err error) {
+	matches := reUUIDHex.FindStringSubmatch(strings.TrimSpace(id))
+	if matches == nil {
+		return b, fmt.Errorf("invalid UUID %q", id)
+	}
+	_, err = hex.Decode(b[:], []byte(strings.Join(matches[2:], "")))
+	return b, err
+}
+
+// UUIDToTime decodes the timestamp of a version 1, 6, or 7 UUID. Unlike
+// GetIDFormat it accepts the undashed form, so only use it on known UUIDs.
+func UUIDToTime(id string) (t time.Time, err error) {
+	b, err := uuidBytes(id)
+	if err != nil {
+		return t, err
+	}
+
+	var ticks uint64 // 100 nanosecond intervals since 1582-10-15
+	switch version := b[6] >> 4; version {
+	case 1:
+		ticks = uint64(b[6]&0x0f)<<56 | uint64(b[7])<<48 | uint64(b[4])<<40 | uint64(b[5])<<32 |
+			uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+	case 6:
+		ticks = uint64(b[0])<<52 | uint64(b[1])<<44 | uint64(b[2])<<36 | uint64(b[3])<<28 |
+			uint64(b[4])<<20 | uint64(b[5])<<12 | uint64(b[6]&0x0f)<<8 | uint64(b[7])
+	case 7:
+		ms := int64(b[0])<<40 | int64(b[1])<<32 | int64(b[2])<<24 | int64(b[3])<<16 | int64(b[4])<<8 | int64(b[5])
+		return UnixMilliToTime(ms), nil
+	default:
+		return t, fmt.Errorf("UUID version %d does not contain a timestamp", version)
+	}
+
+	return time.Unix(int64(ticks/1e7)-uuidEpochOffset, int64(ticks%1e7)*100), nil
+}
+
+// ULIDToTime decodes the millisecond timestamp in the first 10 characters of a ULID
+func ULIDToTime(id string) (t time.Time, err error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	if !reULID.MatchString(id) {
+		return t, fmt.Errorf("invalid ULID %q", id)
+	}
+
+	var ms int64
+	for _, c := range id[:10] {
+		ms = ms<<5 | int64(strings.IndexRune(crockfordBase32, c))
+	}
+	return UnixMilliToTime(ms), nil
+}
+
+// isKSUID reports whether id is 27 base62 digits no larger than the largest KSUID
+func isKSUID(id string) bool {
+	return reKSUID.MatchString(id) && id <= ksuidMax
+}
+
+// ksuidBytes decodes the 20 bytes of a base62 KSUID
+func ksuidBytes(id string) (b [20]byte, err error) {
+	n := new(big.Int)
+	sixtyTwo := big.NewInt(62)
+	for _, c := range id {
+		i := strings.IndexRune(base62, c)
+		if i < 0 {
+			return b, fmt.Errorf("invalid KSUID %q", id)
+		}
+		n.Mul(n, sixtyTwo).Add(n, big.NewInt(int64(i)))
+	}
+	if n.BitLen() > 160 {
+		return b, fmt.Errorf("invalid KSUID %q", id)
+	}
+	n.FillBytes(b[:])
+	return b, nil
+}
+
+// KSUIDToTime decodes the timestamp (seconds since 2014-05-13 16:53:20 UTC) of a KSUID
+func KSUIDToTime(id string) (t time.Time, err error) {
+	id = strings.TrimSpace(id)
+	if !isKSUID(id) {
+		return t, fmt.Errorf("invalid KSUID %q", id)
+	}
+	b, err := ksuidBytes(id)
+	if err != nil {
+		return t, err
+	}
+	sec := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	return time.Unix(sec+ksuidEpoch, 0), nil
+}
+
+// ObjectIDToTime decodes the timestamp in the first 4 bytes of a MongoDB ObjectID
+func ObjectIDToTime(id string) (t time.Time, err error) {
+	matches := reObjectID.FindStringSubmatch(strings.TrimSpace(id))
+	if matches == nil {
+		return t, fmt.Errorf("invalid ObjectID %q", id)
+	}
+	sec, err := strconv.ParseInt(matches[2][:8], 16, 64)
+	if err != nil {
+		return t, err
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// ParseSnowflakeEpoch converts twitter, discord, a UNIX timestamp in milliseconds,
+// or any date-time Parse understands into a Snowflake epoch
+func ParseSnowflakeEpoch(name string) (epoch time.Time, err error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "twitter", "x":
+		return TwitterSnowflakeEpoch, nil
+	case "discord":
+		return DiscordSnowflakeEpoch, nil
+	}
+	if ms, err := strconv.ParseInt(name, 10, 64); err == nil {
+		return UnixMilliToTime(ms).UTC(), nil
+	}
+	epoch, err = Parse(name)
+	if err != nil {
+		return epoch, fmt.Errorf("unknown Snowflake epoch %q (expected twitter, discord, milliseconds, or a date-time)", name)
+	}
+	return epoch, nil
+}
+
+// SnowflakeFromTime returns the lowest Snowflake ID for the millisecond containing t
+func SnowflakeFromTime(t time.Time, epoch time.Time) int64 {
+	return (UnixMilli(t) - UnixMilli(epoch)) << 22
+}
+
+// SnowflakeToTime decodes the millisecond timestamp in the 41 bits below the sign bit of a Snowflake ID
+func SnowflakeToTime(id int64, epoch time.Time) time.Time {
+	return epoch.Add(time.Duration(id>>22) * time.Millisecond)
+}
+
+// ParseSnowflake decodes a decimal Snowflake ID using SnowflakeEpoch
+func ParseSnowflake(s string) (t time.Time, err error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id < 0 {
+		return t, fmt.Errorf("invalid Snowflake ID %q", s)
+	}
+	return SnowflakeToTime(id, SnowflakeEpoch), nil
+}
+
+// IDBound selects which of the IDs for an instant to generate
+type IDBound int
+
+const (
+	// IDRandom fills the non-time part of the ID with random bits
+	IDRandom IDBound = iota
+	// IDMin is the smallest ID for the instant, useful as an inclusive range scan start
+	IDMin
+	// IDMax is the largest ID for the instant, useful as an inclusive range scan end
+	IDMax
+)
+
+// IDTypes lists the ID types GenerateID accepts in display order
+var IDTypes = []string{"ulid", "uuidv7", "ksuid", "objectid", "snowflake"}
+
+// GenerateID returns an ID of the named type (see IDTypes) for t along with the
+// ID format it produced (e.g. UUIDv7Format)
+func GenerateID(idType string, t time.Time, bound IDBound) (id, format string, err error) {
+	switch strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(idType)) {
+	case "ulid":
+		id, err = NewULID(t, bound)
+		return id, ULIDFormat, err
+	case "uuidv7", "uuid7", "uuid":
+		id, err = NewUUIDv7(t, bound)
+		return id, UUIDv7Format, err
+	case "ksuid":
+		id, err = NewKSUID(t, bound)
+		return id, KSUIDFormat, err
+	case "objectid", "mongodb", "mongo":
+		id, err = NewObjectID(t, bound)
+		return id, ObjectIDFormat, err
+	case "snowflake":
+		var sf int64
+		sf, err = NewSnowflake(t, SnowflakeEpoch, bound)
+		return strconv.FormatInt(sf, 10), SnowflakeFormat, err
+	}
+	return "", "", fmt.Errorf("unknown ID type %q (expected one of %s)", idType, strings.Join(IDTypes, ", "))
+}
+
+// fillIDBits sets the non-time part of an ID according to bound
+func fillIDBits(b []byte, bound IDBound) error {
+	switch bound {
+	case IDMin:
+		for i := range b {
+			b[i] = 0
+		}
+	case IDMax:
+		for i := range b {
+			b[i] = 0xff
+		}
+	default:
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putUint48 stores the low 48 bits of v big endian
+func putUint48(b []byte, v int64) {
+	for i := 0; i < 6; i++ {
+		b[i] = byte(v >> uint(40-8*i))
+	}
+}
+
+// encodeBase encodes b as a big endian number in the alphabet, left padded to width
+func encodeBase(b []byte, alphabet string, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
+
+// NewULID returns a ULID for the millisecond containing t
+func NewULID(t time.Time, bound IDBound) (string, error) {
+	ms := UnixMilli(t)
+	if ms < 0 || ms >= 1<<48 {
+		return "", fmt.Errorf("time %s is outside the ULID range", t.Format(time.RFC3339))
+	}
+	var b [16]byte
+	putUint48(b[:], ms)
+	if err := fillIDBits(b[6:], bound); err != nil {
+		return "", err
+	}
+	return encodeBase(b[:], crockfordBase32, 26), nil
+}
+
+// NewUUIDv7 returns a version 7 UUID for the millisecond containing t
+func NewUUIDv7(t time.Time, bound IDBound) (string, error) {
+	ms := UnixMilli(t)
+	if ms < 0 || ms >= 1<<48 {
+		return "", fmt.Errorf("time %s is outside the UUID v7 range", t.Format(time.RFC3339))
+	}
+	var b [16]byte
+	putUint48(b[:], ms)
+	if err := fillIDBits(b[6:], bound); err != nil {
+		return "", err
+	}
+	b[6] = 0x70 | b[6]&0x0f // version 7
+	b[8] = 0x80 | b[8]&0x3f // RFC 9562 variant
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+// NewKSUID returns a KSUID for the second containing t
+func NewKSUID(t time.Time, bound IDBound) (string, error) {
+	sec := t.Unix() - ksuidEpoch
+	if sec < 0 || sec >= 1<<32 {
+		return "", fmt.Errorf("time %s is outside the KSUID range", t.Format(time.RFC3339))
+	}
+	var b [20]byte
+	b[0], b[1], b[2], b[3] = byte(sec>>24), byte(sec>>16), byte(sec>>8), byte(sec)
+	if err := fillIDBits(b[4:], bound); err != nil {
+		return "", err
+	}
+	return encodeBase(b[:], base62, 27), nil
+}
+
+// NewObjectID returns a MongoDB ObjectID for the second containing t
+func NewObjectID(t time.Time, bound IDBound) (string, error) {
+	sec := t.Unix()
+	if sec < 0 || sec >= 1<<32 {
+		return "", fmt.Errorf("time %s is outside the ObjectID range", t.Format(time.RFC3339))
+	}
+	var b [12]byte
+	b[0], b[1], b[2], b[3] = byte(sec>>24), byte(sec>>16), byte(sec>>8), byte(sec)
+	if err := fillIDBits(b[4:], bound); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// NewSnowflake returns a Snowflake ID for the millisecond containing t
+func NewSnowflake(t time.Time, epoch time.Time, bound IDBound) (int64, error) {
+	ms := UnixMilli(t) - UnixMilli(epoch)
+	if ms < 0 || ms >= 1<<41 {
+		return 0, fmt.Errorf("time %s is outside the Snowflake range for epoch %s", t.Format(time.RFC3339), epoch.Format(time.RFC3339))
+	}
+	var b [3]byte
+	if err := fillIDBits(b[:], bound); err != nil {
+		return 0, err
+	}
+	low := (int64(b[0])<<16 | int64(b[1])<<8 | int64(b[2])) & (1<<22 - 1)
+	return ms<<22 | low, nil
+}
@@ -0,0 +1,140 @@
+package chronus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		id     string
+		format string
+		want   string
+	}{
+		// RFC 9562 appendix A test vectors
+		{"C232AB00-9414-11EC-B3C8-9F6BDECED846", UUIDv1Format, "2022-02-22T19:22:22Z"},
+		{"1EC9414C-232A-6B00-B3C8-9F6BDECED846", UUIDv6Format, "2022-02-22T19:22:22Z"},
+		{"017F22E2-79B0-7CC3-98C4-DC0C0C07398F", UUIDv7Format, "2022-02-22T19:22:22Z"},
+		{"urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f", UUIDv7Format, "2022-02-22T19:22:22Z"},
+		{"{017F22E279B07CC398C4DC0C0C07398F}", UUIDv7Format, "2022-02-22T19:22:22Z"},
+		{"01F0B6HW5B8ZJ5Z9VQ2T5N6Y3K", ULIDFormat, "2021-03-09T09:54:39.403Z"},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", KSUIDFormat, "2017-10-10T04:00:47Z"},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80V", KSUIDFormat, "2150-06-19T23:21:35Z"},
+		{"60464d0a0000000000000000", ObjectIDFormat, "2021-03-08T16:12:58Z"},
+		{`ObjectId("60464d0a0000000000000000")`, ObjectIDFormat, "2021-03-08T16:12:58Z"},
+	}
+	for _, tt := range tests {
+		got, format, err := ParseID(tt.id)
+		if err != nil {
+			t.Errorf("ParseID(%q) error: %s", tt.id, err)
+			continue
+		}
+		if format != tt.format || got.UTC().Format(time.RFC3339Nano) != tt.want {
+			t.Errorf("ParseID(%q) = %s, %q, want %s, %q", tt.id, got.UTC().Format(time.RFC3339Nano), format, tt.want, tt.format)
+		}
+	}
+}
+
+func TestGetIDFormatRejects(t *testing.T) {
+	for _, s := range []string{
+		"d41d8cd98f00b204e9800998ecf8427e",          // MD5 of nothing, version nibble 11
+		"9e107d9d372bb6826bd81d3542a419d6",          // MD5 whose version nibble reads as 6
+		"0bc2f4e2d26a11ebb9d10242ac130003",          // undashed v1 UUID
+		"aWgEPTl1tmebfsQzFP4bxwgy80W",               // one past the largest KSUID
+		"zzzzzzzzzzzzzzzzzzzzzzzzzzz",               // 27 characters but too large for 160 bits
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO",                // 26 characters
+		"d41d8cd9-8f00-b204-e980-0998ecf8427e-ffff", // too many groups
+	} {
+		if format := GetIDFormat(s); format != "" {
+			t.Errorf("GetIDFormat(%q) = %q, want none", s, format)
+		}
+	}
+
+	// a UUID without a timestamp is still reported as one
+	if _, _, err := ParseID("9f86d081-884c-4d63-9a2f-2ea08a1b3fc1"); err == nil || !strings.Contains(err.Error(), "version 4") {
+		t.Errorf("ParseID(v4) error = %v, want a version 4 error", err)
+	}
+	if p, err := ParseDetailed("d41d8cd98f00b204e9800998ecf8427e"); err == nil || p.IDType != "" || strings.Contains(err.Error(), "UUID") {
+		t.Errorf("ParseDetailed(MD5) = %+v, %v, want a parse error that is not about UUIDs", p, err)
+	}
+}
+
+func TestUUIDToTimeUndashed(t *testing.T) {
+	got, err := UUIDToTime("c232ab00941411ecb3c89f6bdeced846")
+	if err != nil || !got.Equal(time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)) {
+		t.Errorf("UUIDToTime(undashed v1) = %s, %v", got, err)
+	}
+}
+
+func TestSnowflakeToTime(t *testing.T) {
+	got := SnowflakeToTime(1541815603606036480, TwitterSnowflakeEpoch)
+	if want := time.Date(2022, 6, 28, 16, 7, 40, 105000000, time.UTC); !got.Equal(want) {
+		t.Errorf("SnowflakeToTime(twitter) = %s, want %s", got.UTC(), want)
+	}
+	got = SnowflakeToTime(175928847299117063, DiscordSnowflakeEpoch)
+	if want := time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC); !got.Equal(want) {
+		t.Errorf("SnowflakeToTime(discord) = %s, want %s", got.UTC(), want)
+	}
+
+	// the snowflake epoch system formats the lowest ID of the millisecond
+	epoch, _ := GetEpoch("snowflake")
+	ts := time.Date(2022, 6, 28, 16, 7, 40, 105999999, time.UTC)
+	if id := epoch.Format(ts); id != "1541815603604488192" {
+		t.Errorf("snowflake Format(%s) = %s, want 1541815603604488192", ts, id)
+	} else if got, err := ParseEpoch("snowflake", id); err != nil || !got.Equal(ts.Truncate(time.Millisecond)) {
+		t.Errorf("ParseEpoch(snowflake, %s) = %s, %v, want %s", id, got.UTC(), err, ts.Truncate(time.Millisecond))
+	}
+	if _, err := ParseSnowflake("-1"); err == nil {
+		t.Error("ParseSnowflake(-1) did not fail")
+	}
+}
+
+func TestGenerateIDRoundTrip(t *testing.T) {
+	defer func(epoch time.Time) { SnowflakeEpoch = epoch }(SnowflakeEpoch)
+	SnowflakeEpoch = DiscordSnowflakeEpoch
+
+	ts := time.Date(2021, 3, 8, 23, 6, 34, 186641000, time.UTC)
+	for _, idType := range IDTypes {
+		min, format, err := GenerateID(idType, ts, IDMin)
+		if err != nil {
+			t.Errorf("GenerateID(%s, IDMin) error: %s", idType, err)
+			continue
+		}
+		max, _, _ := GenerateID(idType, ts, IDMax)
+		random, _, _ := GenerateID(idType, ts, IDRandom)
+
+		precision := time.Millisecond
+		if idType == "ksuid" || idType == "objectid" {
+			precision = time.Second
+		}
+		for _, id := range []string{min, random, max} {
+			var got time.Time
+			if format == SnowflakeFormat {
+				got, err = ParseSnowflake(id)
+			} else {
+				var decoded string
+				got, decoded, err = ParseID(id)
+				if decoded != format {
+					t.Errorf("ParseID(%q) format = %q, want %q", id, decoded, format)
+				}
+			}
+			if err != nil || !got.Equal(ts.Truncate(precision)) {
+				t.Errorf("%s %q decodes to %s, %v, want %s", idType, id, got.UTC(), err, ts.Truncate(precision))
+			}
+		}
+		if format != SnowflakeFormat && !(min <= random && random <= max) {
+			t.Errorf("%s IDs do not sort: %s %s %s", idType, min, random, max)
+		}
+	}
+
+	if _, _, err := GenerateID("uuidv4", ts, IDRandom); err == nil {
+		t.Errorf("GenerateID(uuidv4) should fail")
+	}
+	if _, err := NewKSUID(time.Unix(ksuidEpoch-1, 0), IDMin); err == nil {
+		t.Errorf("NewKSUID before the KSUID epoch should fail")
+	}
+	if got := SnowflakeFromTime(ts, DiscordSnowflakeEpoch); !SnowflakeToTime(got, DiscordSnowflakeEpoch).Equal(ts.Truncate(time.Millisecond)) {
+		t.Errorf("SnowflakeFromTime(%s) = %d does not round trip", ts, got)
+	}
+}
@@ -0,0 +1,841 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ldmlToken is either a run of a single pattern letter (e.g. "yyyy") or literal text
+type ldmlToken struct {
+	letter  byte
+	count   int
+	literal string
+}
+
+// numeric reports whether the token is parsed as a plain number
+func (tok ldmlToken) numeric() bool {
+	switch tok.letter {
+	case 'y', 'Y', 'u', 'd', 'D', 'F', 'H', 'h', 'K', 'k', 'm', 's', 'S', 'A', 'w':
+		return true
+	case 'M', 'L', 'e', 'c', 'Q', 'q':
+		return tok.count <= 2
+	}
+	return false
+}
+
+const (
+	// ldmlLetters are the LDML pattern letters FormatLDML supports
+	ldmlLetters = "GyuYQqMLwdDFecEahHKkmsSAzZOVvXx"
+
+	// momentLetters are the letters of the moment.js and day.js tokens (o is
+	// from the ordinal tokens such as Do, which MomentToLDML rejects)
+	momentLetters = "YgGQMDdoEWwHhkmsSAaZzXx"
+)
+
+var reMomentLiteral = regexp.MustCompile(`\[[^\]]*\]`)
+
+// IsLDML reports whether the format looks like a Unicode LDML pattern (as used by
+// Java's DateTimeFormatter, ICU, and moment.js) rather than a strftime format or
+// Go layout. Go layouts always contain digits from the reference time or words
+// such as Monday, January, and MST, while LDML patterns use only pattern
+// letters outside of quotes.
+func IsLDML(format string) bool {
+	if IsStrftime(format) || strings.ContainsAny(format, "0123456789") {
+		return false
+	}
+	tokens, err := tokenizeLDML(format)
+	if err != nil {
+		return false
+	}
+	dateOrTime := false
+	for _, tok := range tokens {
+		if tok.letter == 0 {
+			continue
+		}
+		if !strings.ContainsRune(ldmlLetters, rune(tok.letter)) {
+			return false
+		}
+		dateOrTime = dateOrTime || strings.ContainsRune("yYuMLdDHhKkms", rune(tok.letter))
+	}
+	return dateOrTime
+}
+
+// IsMoment reports whether the format is using moment.js and day.js tokens, where
+// YYYY is the calendar year, DD the day of the month, and literal text is in
+// square brackets. Java patterns nearly always use the lowercase y for the year.
+func IsMoment(format string) bool {
+	if IsStrftime(format) || strings.ContainsAny(format, "0123456789") {
+		return false
+	}
+	bracketed := strings.Contains(format, "[")
+	format = reMomentLiteral.ReplaceAllString(format, "")
+	for _, r := range format {
+		if r < 128 && unicode.IsLetter(r) && !strings.ContainsRune(momentLetters, r) {
+			return false
+		}
+	}
+	return bracketed || (strings.Contains(format, "YY") && strings.Contains(format, "D"))
+}
+
+// tokenizeLDML splits a pattern into letter runs and literals. Text in single
+// quotes is literal and two single quotes are an escaped quote.
+func tokenizeLDML(pattern string) (tokens []ldmlToken, err error) {
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, ldmlToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				literal.WriteByte('\'')
+				i += 2
+				continue
+			}
+			end := i + 1
+			for {
+				j := strings.IndexByte(pattern[end:], '\'')
+				if j < 0 {
+					return nil, fmt.Errorf("ldml: unterminated quote in %q", pattern)
+				}
+				literal.WriteString(pattern[end : end+j])
+				end += j + 1
+				if end < len(pattern) && pattern[end] == '\'' {
+					literal.WriteByte('\'')
+					end++
+					continue
+				}
+				break
+			}
+			i = end
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			flush()
+			j := i
+			for j < len(pattern) && pattern[j] == c {
+				j++
+			}
+			tokens = append(tokens, ldmlToken{letter: c, count: j - i})
+			i = j
+		default:
+			literal.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+
+	return tokens, nil
+}
+
+// FormatLDML formats t with a Unicode LDML pattern such as yyyy-MM-dd'T'HH:mm:ss.SSSXXX
+func FormatLDML(t time.Time, pattern string) (string, error) {
+	tokens, err := tokenizeLDML(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, tok := range tokens {
+		if tok.letter == 0 {
+			b.WriteString(tok.literal)
+			continue
+		}
+		s, err := ldmlValue(t, tok)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+	}
+
+	return b.String(), nil
+}
+
+func ldmlPad(n, count int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + ldmlPad(-n, count)
+	}
+	if len(s) < count {
+		s = strings.Repeat("0", count-len(s)) + s
+	}
+	return s
+}
+
+func ldmlValue(t time.Time, tok ldmlToken) (string, error) {
+	n := tok.count
+	_, offset := t.Zone()
+
+	switch tok.letter {
+	case 'G':
+		era, long := "AD", "Anno Domini"
+		if t.Year() <= 0 {
+			era, long = "BC", "Before Christ"
+		}
+		if n == 4 {
+			return long, nil
+		}
+		return era, nil
+	case 'y', 'u':
+		year := t.Year()
+		if tok.letter == 'y' && year <= 0 {
+			year = 1 - year
+		}
+		if n == 2 {
+			return ldmlPad(year%100, 2), nil
+		}
+		return ldmlPad(year, n), nil
+	case 'Y':
+		year, _ := t.ISOWeek()
+		if n == 2 {
+			return ldmlPad(year%100, 2), nil
+		}
+		return ldmlPad(year, n), nil
+	case 'Q', 'q':
+		quarter := (int(t.Month())-1)/3 + 1
+		switch {
+		case n <= 2:
+			return ldmlPad(quarter, n), nil
+		case n == 3:
+			return "Q" + strconv.Itoa(quarter), nil
+		}
+		return [4]string{"1st", "2nd", "3rd", "4th"}[quarter-1] + " quarter", nil
+	case 'M', 'L':
+		switch {
+		case n <= 2:
+			return ldmlPad(int(t.Month()), n), nil
+		case n == 3:
+			return t.Month().String()[:3], nil
+		case n == 4:
+			return t.Month().String(), nil
+		}
+		return t.Month().String()[:1], nil
+	case 'w':
+		_, week := t.ISOWeek()
+		return ldmlPad(week, n), nil
+	case 'd':
+		return ldmlPad(t.Day(), n), nil
+	case 'D':
+		return ldmlPad(t.YearDay(), n), nil
+	case 'F':
+		return ldmlPad((t.Day()-1)/7+1, n), nil
+	case 'e', 'c':
+		if n <= 2 {
+			return ldmlPad((int(t.Weekday())+6)%7+1, n), nil
+		}
+		fallthrough
+	case 'E':
+		switch {
+		case n <= 3:
+			return t.Weekday().String()[:3], nil
+		case n == 4:
+			return t.Weekday().String(), nil
+		case n == 5:
+			return t.Weekday().String()[:1], nil
+		}
+		return t.Weekday().String()[:2], nil
+	case 'a':
+		return t.Format("PM"), nil
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return ldmlPad(hour, n), nil
+	case 'H':
+		return ldmlPad(t.Hour(), n), nil
+	case 'K':
+		return ldmlPad(t.Hour()%12, n), nil
+	case 'k':
+		hour := t.Hour()
+		if hour == 0 {
+			hour = 24
+		}
+		return ldmlPad(hour, n), nil
+	case 'm':
+		return ldmlPad(t.Minute(), n), nil
+	case 's':
+		return ldmlPad(t.Second(), n), nil
+	case 'S':
+		s := fmt.Sprintf("%09d", t.Nanosecond())
+		if n <= 9 {
+			return s[:n], nil
+		}
+		return s + strings.Repeat("0", n-9), nil
+	case 'A':
+		millis := ((t.Hour()*60+t.Minute())*60+t.Second())*1000 + t.Nanosecond()/1e6
+		return ldmlPad(millis, n), nil
+	case 'z':
+		zone, _ := t.Zone()
+		if n == 4 && t.Location().String() != "Local" {
+			return t.Location().String(), nil
+		}
+		if len(zone) == 0 {
+			return ldmlGMTOffset(offset, true), nil
+		}
+		return zone, nil
+	case 'Z':
+		switch {
+		case n <= 3:
+			return strftimeOffset(offset, 0), nil
+		case n == 4:
+			return ldmlGMTOffset(offset, true), nil
+		}
+		if offset == 0 {
+			return "Z", nil
+		}
+		return strftimeOffset(offset, 1), nil
+	case 'O':
+		return ldmlGMTOffset(offset, n == 4), nil
+	case 'V', 'v':
+		return t.Location().String(), nil
+	case 'X', 'x':
+		if tok.letter == 'X' && offset == 0 {
+			return "Z", nil
+		}
+		switch n {
+		case 1:
+			s := strftimeOffset(offset, 0)
+			if strings.HasSuffix(s, "00") {
+				return s[:3], nil
+			}
+			return s, nil
+		case 2, 4:
+			return strftimeOffset(offset, 0), nil
+		}
+		return strftimeOffset(offset, 1), nil
+	}
+
+	return "", fmt.Errorf("ldml: unsupported pattern letter %q", strings.Repeat(string(tok.letter), n))
+}
+
+// ldmlGMTOffset returns the localized GMT format such as GMT-7 or GMT-07:00
+func ldmlGMTOffset(offset int, long bool) string {
+	if offset == 0 {
+		return "GMT"
+	}
+	if long {
+		return "GMT" + strftimeOffset(offset, 1)
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	if offset%3600 == 0 {
+		return fmt.Sprintf("GMT%s%d", sign, offset/3600)
+	}
+	return fmt.Sprintf("GMT%s%d:%02d", sign, offset/3600, offset/60%60)
+}
+
+// ParseLDML parses value with a Unicode LDML pattern. Numeric fields directly
+// followed by another numeric field (e.g. yyyyMMdd) are read using the width of
+// the pattern letters. Two digit years use the POSIX pivot (69-99 are 19xx).
+func ParseLDML(value, pattern string) (t time.Time, err error) {
+	tokens, err := tokenizeLDML(pattern)
+	if err != nil {
+		return t, err
+	}
+
+	f := strptimeFields{month: 1, day: 1}
+	bc := false
+
+	for i, tok := range tokens {
+		if tok.letter == 0 {
+			if !strings.HasPrefix(value, tok.literal) {
+				return t, fmt.Errorf("ldml: expected %q in %q", tok.literal, value)
+			}
+			value = value[len(tok.literal):]
+			continue
+		}
+
+		width := 0
+		if i+1 < len(tokens) && tokens[i+1].letter != 0 && tokens[i+1].numeric() && tok.numeric() {
+			width = tok.count
+			if tok.letter == 'y' && tok.count == 1 {
+				width = 4
+			}
+		}
+
+		value, err = ldmlParseToken(value, tok, width, &f, &bc)
+		if err != nil {
+			return t, err
+		}
+	}
+
+	if len(value) > 0 {
+		return t, fmt.Errorf("ldml: extra text %q after %q", value, pattern)
+	}
+	if bc {
+		f.year = 1 - f.year
+	}
+
+	return f.time()
+}
+
+// ldmlInt reads a number with exactly width digits or, when width is zero, up to maxDigits
+func ldmlInt(value string, width, maxDigits int) (n int, rest string, err error) {
+	if width > 0 {
+		if len(value) < width {
+			return 0, value, fmt.Errorf("ldml: expected %d digits in %q", width, value)
+		}
+		n, err = strconv.Atoi(value[:width])
+		if err != nil {
+			return 0, value, fmt.Errorf("ldml: expected %d digits in %q", width, value)
+		}
+		return n, value[width:], nil
+	}
+	n, rest, err = strptimeInt(value, maxDigits, false)
+	if err != nil {
+		err = fmt.Errorf("ldml: expected number in %q", value)
+	}
+	return n, rest, err
+}
+
+func ldmlParseToken(value string, tok ldmlToken, width int, f *strptimeFields, bc *bool) (rest string, err error) {
+	n := tok.count
+
+	switch tok.letter {
+	case 'G':
+		upper := strings.ToUpper(value)
+		for _, era := range []string{"BEFORE CHRIST", "ANNO DOMINI", "BCE", "CE", "BC", "AD"} {
+			if strings.HasPrefix(upper, era) {
+				*bc = strings.HasPrefix(era, "B")
+				return value[len(era):], nil
+			}
+		}
+		return value, fmt.Errorf("ldml: expected era in %q", value)
+	case 'y', 'u', 'Y':
+		if n == 2 {
+			f.year, value, err = ldmlInt(value, 2, 2)
+			if f.year < 69 {
+				f.year += 2000
+			} else {
+				f.year += 1900
+			}
+		} else {
+			f.year, value, err = ldmlInt(value, width, 9)
+		}
+		if tok.letter == 'Y' {
+			f.isoYear = f.year
+			f.hasISOYear = true
+		}
+		f.hasYear = true
+	case 'Q', 'q':
+		if n <= 2 {
+			_, value, err = ldmlInt(value, width, 1)
+			return value, err
+		}
+		value = strings.TrimPrefix(value, "Q")
+		if len(value) == 0 || value[0] < '1' || value[0] > '4' {
+			return value, fmt.Errorf("ldml: expected quarter in %q", value)
+		}
+		value = value[1:]
+		if n == 4 {
+			for _, suffix := range []string{"st", "nd", "rd", "th"} {
+				value = strings.TrimPrefix(value, suffix)
+			}
+			value = strings.TrimPrefix(value, " quarter")
+		}
+		return value, nil
+	case 'M', 'L':
+		if n <= 2 {
+			f.month, value, err = ldmlInt(value, width, 2)
+			return value, err
+		}
+		var index int
+		index, value, err = strptimeName(value, englishMonths[:], englishMonthsAbbr[:])
+		f.month = index + 1
+		return value, err
+	case 'w':
+		f.isoWeek, value, err = ldmlInt(value, width, 2)
+		f.hasISOWeek = true
+	case 'd':
+		f.day, value, err = ldmlInt(value, width, 2)
+	case 'D':
+		f.yearDay, value, err = ldmlInt(value, width, 3)
+		f.hasYearDay = true
+	case 'F':
+		_, value, err = ldmlInt(value, width, 1)
+	case 'e', 'c':
+		if n <= 2 {
+			var weekday int
+			weekday, value, err = ldmlInt(value, width, 1)
+			f.weekday = weekday % 7
+			f.hasWeekday = true
+			return value, err
+		}
+		fallthrough
+	case 'E':
+		f.weekday, value, err = strptimeName(value, englishWeekdays[:], englishWeekdaysAbbr[:])
+		f.hasWeekday = true
+	case 'a':
+		return strptimeDirective(value, strftimeDirective{verb: 'p'}, f)
+	case 'h', 'K':
+		f.hour, value, err = ldmlInt(value, width, 2)
+		f.hour %= 12
+	case 'H', 'k':
+		f.hour, value, err = ldmlInt(value, width, 2)
+		f.hour %= 24
+	case 'm':
+		f.minute, value, err = ldmlInt(value, width, 2)
+	case 's':
+		f.second, value, err = ldmlInt(value, width, 2)
+	case 'S':
+		digits := width
+		if digits == 0 {
+			for digits < len(value) && digits < 9 && value[digits] >= '0' && value[digits] <= '9' {
+				digits++
+			}
+		}
+		if digits == 0 || digits > len(value) {
+			return value, fmt.Errorf("ldml: expected fractional seconds in %q", value)
+		}
+		frac := value[:digits]
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		f.nanosecond, err = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+		value = value[digits:]
+	case 'A':
+		var millis int
+		millis, value, err = ldmlInt(value, width, 8)
+		f.hour, f.minute, f.second = millis/3600000, millis/60000%60, millis/1000%60
+		f.nanosecond = millis % 1000 * 1e6
+	case 'z':
+		i := 0
+		for i < len(value) && (unicode.IsLetter(rune(value[i])) || value[i] == '/' || value[i] == '_') {
+			i++
+		}
+		if i == 0 {
+			return value, fmt.Errorf("ldml: expected time zone in %q", value)
+		}
+		if loc, lerr := time.LoadLocation(value[:i]); lerr == nil && strings.Contains(value[:i], "/") {
+			f.location = loc
+		} else {
+			f.location = strptimeZone(value[:i])
+		}
+		value = value[i:]
+	case 'V', 'v':
+		i := strings.IndexAny(value, " \t")
+		if i < 0 {
+			i = len(value)
+		}
+		loc, lerr := time.LoadLocation(value[:i])
+		if lerr != nil {
+			return value, fmt.Errorf("ldml: unknown time zone %q", value[:i])
+		}
+		f.location = loc
+		value = value[i:]
+	case 'O', 'Z', 'X', 'x':
+		if strings.HasPrefix(value, "GMT") || strings.HasPrefix(value, "UTC") {
+			value = value[3:]
+			if len(value) == 0 || (value[0] != '+' && value[0] != '-') {
+				f.location = time.UTC
+				return value, nil
+			}
+			return ldmlParseGMTOffset(value, f)
+		}
+		return strptimeOffset(value, f)
+	default:
+		return value, fmt.Errorf("ldml: unsupported pattern letter %q", strings.Repeat(string(tok.letter), n))
+	}
+
+	return value, err
+}
+
+// ldmlParseGMTOffset reads the offset after GMT where the hours may be a single digit (GMT-7)
+func ldmlParseGMTOffset(value string, f *strptimeFields) (rest string, err error) {
+	sign := value[0]
+	hours, rest, err := strptimeInt(value[1:], 2, false)
+	if err != nil {
+		return value, err
+	}
+	minutes := 0
+	if strings.HasPrefix(rest, ":") {
+		minutes, rest, err = strptimeInt(rest[1:], 2, false)
+		if err != nil {
+			return value, err
+		}
+	}
+
+	offset := hours*3600 + minutes*60
+	if sign == '-' {
+		offset = -offset
+	}
+	f.location = time.FixedZone("", offset)
+
+	return rest, nil
+}
+
+// LDMLToLayout converts an LDML pattern into the equivalent Go layout. Pattern
+// letters without a Go layout equivalent (such as D, w, or Q) and literal text
+// that Go would mistake for part of the layout return an error.
+func LDMLToLayout(pattern string) (layout string, err error) {
+	tokens, err := tokenizeLDML(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, tok := range tokens {
+		if tok.letter == 0 {
+			if ldmlLiteralConflicts(tok.literal) {
+				return "", fmt.Errorf("ldml: literal %q can not be expressed in a Go layout", tok.literal)
+			}
+			// the Go fractional second is written with its leading separator
+			if (tok.literal == "." || tok.literal == ",") && i+1 < len(tokens) && tokens[i+1].letter == 'S' {
+				continue
+			}
+			b.WriteString(tok.literal)
+			continue
+		}
+
+		chunk := ldmlLayoutChunk(tok)
+		if tok.letter == 'S' {
+			separator := "."
+			if i > 0 && tokens[i-1].literal == "," {
+				separator = ","
+			} else if i == 0 || tokens[i-1].literal != "." {
+				return "", fmt.Errorf("ldml: fractional seconds must follow a '.' or ',' in a Go layout")
+			}
+			chunk = separator + strings.Repeat("0", tok.count)
+		}
+		if len(chunk) == 0 {
+			return "", fmt.Errorf("ldml: pattern letter %q has no Go layout equivalent", strings.Repeat(string(tok.letter), tok.count))
+		}
+		b.WriteString(chunk)
+	}
+
+	return b.String(), nil
+}
+
+func ldmlLayoutChunk(tok ldmlToken) string {
+	n := tok.count
+	switch tok.letter {
+	case 'y', 'u':
+		if n == 2 {
+			return "06"
+		}
+		return "2006"
+	case 'M', 'L':
+		switch n {
+		case 1:
+			return "1"
+		case 2:
+			return "01"
+		case 3:
+			return "Jan"
+		case 4:
+			return "January"
+		}
+	case 'd':
+		if n == 1 {
+			return "2"
+		}
+		return "02"
+	case 'E':
+		if n == 4 {
+			return "Monday"
+		}
+		if n <= 3 {
+			return "Mon"
+		}
+	case 'a':
+		return "PM"
+	case 'h':
+		if n == 1 {
+			return "3"
+		}
+		return "03"
+	case 'H':
+		return "15"
+	case 'm':
+		if n == 1 {
+			return "4"
+		}
+		return "04"
+	case 's':
+		if n == 1 {
+			return "5"
+		}
+		return "05"
+	case 'z':
+		if n <= 3 {
+			return "MST"
+		}
+	case 'Z':
+		if n <= 3 {
+			return "-0700"
+		}
+		if n == 5 {
+			return "Z07:00"
+		}
+	case 'X':
+		switch n {
+		case 1:
+			return "Z07"
+		case 2, 4:
+			return "Z0700"
+		case 3, 5:
+			return "Z07:00"
+		}
+	case 'x':
+		switch n {
+		case 1:
+			return "-07"
+		case 2, 4:
+			return "-0700"
+		case 3, 5:
+			return "-07:00"
+		}
+	}
+	return ""
+}
+
+// ldmlLiteralConflicts reports whether literal text would be read by Go as a layout element
+func ldmlLiteralConflicts(literal string) bool {
+	if strings.ContainsAny(literal, "0123456789") {
+		return true
+	}
+	for _, std := range []string{"Jan", "Mon", "MST", "PM", "pm"} {
+		if strings.Contains(literal, std) {
+			return true
+		}
+	}
+	return false
+}
+
+// goLayoutChunks maps Go layout elements to LDML, longest and most specific first
+var goLayoutChunks = []struct{ layout, ldml string }{
+	{"January", "MMMM"}, {"Jan", "MMM"}, {"Monday", "EEEE"}, {"Mon", "EEE"}, {"MST", "z"},
+	{"2006", "yyyy"}, {"Z07:00:00", "XXXXX"}, {"Z070000", "XXXX"}, {"Z07:00", "XXX"}, {"Z0700", "XX"}, {"Z07", "X"},
+	{"-07:00:00", "xxxxx"}, {"-070000", "xxxx"}, {"-07:00", "xxx"}, {"-0700", "xx"}, {"-07", "x"},
+	{"002", "DDD"}, {"__2", "D"}, {"01", "MM"}, {"02", "dd"}, {"_2", "d"}, {"03", "hh"}, {"04", "mm"}, {"05", "ss"}, {"06", "yy"},
+	{"15", "HH"}, {"1", "M"}, {"2", "d"}, {"3", "h"}, {"4", "m"}, {"5", "s"}, {"PM", "a"}, {"pm", "a"},
+}
+
+// LayoutToLDML converts a Go layout into the equivalent LDML pattern
+func LayoutToLDML(layout string) string {
+	var b strings.Builder
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		s := literal.String()
+		literal.Reset()
+		if strings.IndexFunc(s, func(r rune) bool { return r < 128 && unicode.IsLetter(r) || r == '\'' }) >= 0 {
+			b.WriteString("'" + strings.Replace(s, "'", "''", -1) + "'")
+			return
+		}
+		b.WriteString(s)
+	}
+
+outer:
+	for i := 0; i < len(layout); {
+		// fractional seconds: .000, .999, ,000, or ,999
+		if (layout[i] == '.' || layout[i] == ',') && i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+			j := i + 1
+			for j < len(layout) && layout[j] == layout[i+1] {
+				j++
+			}
+			if j == len(layout) || layout[j] < '0' || layout[j] > '9' {
+				literal.WriteByte(layout[i])
+				flush()
+				b.WriteString(strings.Repeat("S", j-i-1))
+				i = j
+				continue
+			}
+		}
+
+		for _, chunk := range goLayoutChunks {
+			if strings.HasPrefix(layout[i:], chunk.layout) {
+				flush()
+				b.WriteString(chunk.ldml)
+				i += len(chunk.layout)
+				continue outer
+			}
+		}
+
+		literal.WriteByte(layout[i])
+		i++
+	}
+	flush()
+
+	return b.String()
+}
+
+// momentTokens maps moment.js and day.js tokens to LDML, longest first
+var momentTokens = []struct{ moment, ldml string }{
+	{"YYYY", "yyyy"}, {"YY", "yy"}, {"GGGG", "YYYY"}, {"gggg", "YYYY"}, {"Q", "Q"},
+	{"MMMM", "MMMM"}, {"MMM", "MMM"}, {"MM", "MM"}, {"M", "M"},
+	{"DDDD", "DDD"}, {"DDD", "D"}, {"DD", "dd"}, {"D", "d"},
+	{"dddd", "EEEE"}, {"ddd", "EEE"}, {"dd", "EEEEEE"}, {"E", "e"},
+	{"WW", "ww"}, {"W", "w"}, {"ww", "ww"}, {"w", "w"},
+	{"HH", "HH"}, {"H", "H"}, {"hh", "hh"}, {"h", "h"}, {"kk", "kk"}, {"k", "k"},
+	{"mm", "mm"}, {"m", "m"}, {"ss", "ss"}, {"s", "s"},
+	{"SSSSSSSSS", "SSSSSSSSS"}, {"SSSSSS", "SSSSSS"}, {"SSS", "SSS"}, {"SS", "SS"}, {"S", "S"},
+	{"A", "a"}, {"a", "a"}, {"ZZ", "xx"}, {"Z", "xxx"}, {"z", "z"},
+}
+
+// MomentToLDML converts a moment.js or day.js format into an LDML pattern. Text in
+// square brackets is literal. The UNIX timestamp tokens X and x and the ordinal
+// tokens such as Do ("8th") have no LDML equivalent and return an error.
+func MomentToLDML(format string) (pattern string, err error) {
+	var b strings.Builder
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		s := literal.String()
+		literal.Reset()
+		if strings.IndexFunc(s, func(r rune) bool { return r < 128 && unicode.IsLetter(r) || r == '\'' }) >= 0 {
+			b.WriteString("'" + strings.Replace(s, "'", "''", -1) + "'")
+			return
+		}
+		b.WriteString(s)
+	}
+
+outer:
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			end := strings.IndexByte(format[i:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("moment: unterminated [ in %q", format)
+			}
+			literal.WriteString(format[i+1 : i+end])
+			i += end + 1
+			continue
+		}
+		if format[i] == 'X' || format[i] == 'x' {
+			return "", fmt.Errorf("moment: UNIX timestamp token %q has no LDML equivalent", format[i:i+1])
+		}
+
+		for _, tok := range momentTokens {
+			if strings.HasPrefix(format[i:], tok.moment) {
+				flush()
+				if i+len(tok.moment) < len(format) && format[i+len(tok.moment)] == 'o' {
+					return "", fmt.Errorf("moment: ordinal token %q has no LDML equivalent", tok.moment+"o")
+				}
+				b.WriteString(tok.ldml)
+				i += len(tok.moment)
+				continue outer
+			}
+		}
+
+		literal.WriteByte(format[i])
+		i++
+	}
+	flush()
+
+	return b.String(), nil
+}
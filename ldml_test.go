@@ -0,0 +1,147 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsLDML(t *testing.T) {
+	tests := []struct {
+		format string
+		ldml   bool
+		moment bool
+	}{
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSXXX", true, false},
+		{"EEEE, MMMM d, y", true, false},
+		{"dd/MM/yyyy HH:mm", true, false},
+		{"YYYY-MM-DD HH:mm:ss", true, true},
+		{"YYYY-MM-DD [at] HH:mm", false, true},
+		{"dddd, MMMM Do YYYY", false, true},
+		{"[Q]Q YYYY", true, true},
+		{"Monday", false, false},
+		{"January", false, false},
+		{"Mon Jan", false, false},
+		{"MST", false, false},
+		{"2006-01-02 15:04:05", false, false},
+		{"%Y-%m-%d", false, false},
+	}
+	for _, tt := range tests {
+		if got := IsLDML(tt.format); got != tt.ldml {
+			t.Errorf("IsLDML(%q) = %t, want %t", tt.format, got, tt.ldml)
+		}
+		if got := IsMoment(tt.format); got != tt.moment {
+			t.Errorf("IsMoment(%q) = %t, want %t", tt.format, got, tt.moment)
+		}
+	}
+}
+
+func TestFormatLayouts(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 123456789, time.FixedZone("MST", -7*3600))
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"Monday", "Monday"},
+		{"January", "March"},
+		{"Mon Jan", "Mon Mar"},
+		{"MST", "MST"},
+		{"2006-01-02T15:04:05Z07:00", "2021-03-08T16:06:34-07:00"},
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "2021-03-08T16:06:34.123-07:00"},
+		{"EEEE, MMMM d, y h:mm a", "Monday, March 8, 2021 4:06 PM"},
+		{"YYYY-MM-DD [at] HH:mm", "2021-03-08 at 16:06"},
+		{"[Q]Q YYYY", "Q1 2021"},
+		{"%Y-%m-%d %H:%M", "2021-03-08 16:06"},
+	}
+	for _, tt := range tests {
+		got, err := Format(ts, tt.format)
+		if err != nil {
+			t.Errorf("Format(%q) error: %s", tt.format, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestParseLDML(t *testing.T) {
+	tests := []struct {
+		value   string
+		pattern string
+		want    string
+	}{
+		{"2021-03-08T16:06:34.123-07:00", "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "2021-03-08T16:06:34.123-07:00"},
+		{"08/03/2021 16:06", "dd/MM/yyyy HH:mm", "2021-03-08T16:06:00Z"},
+		{"20210308160634", "yyyyMMddHHmmss", "2021-03-08T16:06:34Z"},
+		{"Monday, March 8, 2021", "EEEE, MMMM d, yyyy", "2021-03-08T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := ParseLDML(tt.value, tt.pattern)
+		if err != nil {
+			t.Errorf("ParseLDML(%q, %q) error: %s", tt.value, tt.pattern, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseLDML(%q, %q) = %s, want %s", tt.value, tt.pattern, s, tt.want)
+		}
+	}
+}
+
+func TestMomentToLDML(t *testing.T) {
+	tests := []struct {
+		format  string
+		pattern string // empty when an error is expected
+	}{
+		{"YYYY-MM-DD HH:mm:ss", "yyyy-MM-dd HH:mm:ss"},
+		{"YYYY-MM-DD [at] HH:mm", "yyyy-MM-dd' at 'HH:mm"},
+		{"dddd, MMMM D YYYY", "EEEE, MMMM d yyyy"},
+		{"YYYY [Q]Q", "yyyy' Q'Q"},
+		{"Qo", ""},
+		{"MMMM Do YYYY", ""},
+		{"DDDo", ""},
+		{"X", ""},
+	}
+	for _, tt := range tests {
+		got, err := MomentToLDML(tt.format)
+		if tt.pattern == "" {
+			if err == nil {
+				t.Errorf("MomentToLDML(%q) = %q, want an error", tt.format, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("MomentToLDML(%q) error: %s", tt.format, err)
+			continue
+		}
+		if got != tt.pattern {
+			t.Errorf("MomentToLDML(%q) = %q, want %q", tt.format, got, tt.pattern)
+		}
+	}
+}
+
+func TestLayoutToLDML(t *testing.T) {
+	tests := []struct {
+		layout  string
+		pattern string
+	}{
+		{"2006-01-02T15:04:05.000Z07:00", "yyyy-MM-dd'T'HH:mm:ss.SSSXXX"},
+		{"Monday, January _2 2006 3:04 PM", "EEEE, MMMM d yyyy h:mm a"},
+		{"2006.002", "yyyy.DDD"},
+		{"2006 __2", "yyyy D"},
+		{"02 Jan 06 15:04 MST", "dd MMM yy HH:mm z"},
+		{"2006-01-02 at 15:04", "yyyy-MM-dd' at 'HH:mm"},
+	}
+	// a day and day of year too large for the space padding LDML can not express
+	ts := time.Date(2021, 11, 18, 16, 6, 34, 0, time.UTC)
+	for _, tt := range tests {
+		got := LayoutToLDML(tt.layout)
+		if got != tt.pattern {
+			t.Errorf("LayoutToLDML(%q) = %q, want %q", tt.layout, got, tt.pattern)
+			continue
+		}
+		want := ts.Format(tt.layout)
+		if s, err := FormatLDML(ts, got); err != nil || s != want {
+			t.Errorf("FormatLDML(%q) = %q, %v, want %q", got, s, err, want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package chronus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// gpsTAIOffset is TAI - GPS which is fixed at the 19 leap seconds in effect on 1980-01-06
+	gpsTAIOffset = 19 * time.Second
+
+	// ntpUnixOffset is the number of seconds between the NTP era 0 epoch (1900-01-01) and the UNIX Epoch
+	ntpUnixOffset = 2208988800
+
+	// secondsPerWeek is the length of a GPS week
+	secondsPerWeek = 7 * 86400
+)
+
+// LeapSecond is an entry in the leap second table. From Time (UTC) onward TAI - UTC is Offset seconds.
+type LeapSecond struct {
+	Time   time.Time
+	Offset int
+}
+
+var (
+	// GPSEpoch is the start of GPS time, 1980-01-06 00:00:00 UTC
+	GPSEpoch = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+	// LeapSecondsFile is a leap-seconds.list file to load in place of the embedded table
+	LeapSecondsFile = os.Getenv("CHRONUS_LEAP_SECONDS")
+
+	// LeapSecondsExpire is when the leap second table in use is no longer guaranteed to be complete.
+	// The embedded value is the "File expires on" line of the IERS leap-seconds.list
+	// published with Bulletin C 71 (https://hpiers.obspm.fr/iers/bul/bulc/ntp/leap-seconds.list).
+	LeapSecondsExpire = time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC)
+
+	leapSeconds     = embeddedLeapSeconds()
+	leapSecondsMu   sync.RWMutex
+	leapSecondsOnce sync.Once
+)
+
+// embeddedLeapSeconds is the IERS table of TAI - UTC since whole second offsets began in 1972
+func embeddedLeapSeconds() []LeapSecond {
+	table := []struct {
+		year   int
+		month  time.Month
+		offset int
+	}{
+		{1972, time.January, 10},
+		{1972, time.July, 11},
+		{1973, time.January, 12},
+		{1974, time.January, 13},
+		{1975, time.January, 14},
+		{1976, time.January, 15},
+		{1977, time.January, 16},
+		{1978, time.January, 17},
+		{1979, time.January, 18},
+		{1980, time.January, 19},
+		{1981, time.July, 20},
+		{1982, time.July, 21},
+		{1983, time.July, 22},
+		{1985, time.July, 23},
+		{1988, time.January, 24},
+		{1990, time.January, 25},
+		{1991, time.January, 26},
+		{1992, time.July, 27},
+		{1993, time.July, 28},
+		{1994, time.July, 29},
+		{1996, time.January, 30},
+		{1997, time.July, 31},
+		{1999, time.January, 32},
+		{2006, time.January, 33},
+		{2009, time.January, 34},
+		{2012, time.July, 35},
+		{2015, time.July, 36},
+		{2017, time.January, 37},
+	}
+
+	leaps := make([]LeapSecond, len(table))
+	for i, entry := range table {
+		leaps[i] = LeapSecond{Time: time.Date(entry.year, entry.month, 1, 0, 0, 0, 0, time.UTC), Offset: entry.offset}
+	}
+	return leaps
+}
+
+// LeapSeconds returns a copy of the leap second table in use
+func LeapSeconds() []LeapSecond {
+	leapSecondsOnce.Do(loadLeapSecondsFile)
+	leapSecondsMu.RLock()
+	defer leapSecondsMu.RUnlock()
+	return append([]LeapSecond(nil), leapSeconds...)
+}
+
+// loadLeapSecondsFile replaces the embedded table with LeapSecondsFile when it is set
+func loadLeapSecondsFile() {
+	if len(LeapSecondsFile) == 0 {
+		return
+	}
+	leaps, expire, err := readLeapSecondsFile(LeapSecondsFile)
+	if err != nil {
+		DebugPrintf("chronus.loadLeapSecondsFile() | error: %s\n", err.Error())
+		return
+	}
+	setLeapSeconds(leaps, expire)
+}
+
+// LoadLeapSecondsFile replaces the leap second table with a leap-seconds.list file
+// such as the one distributed by the IERS or found at /usr/share/zoneinfo/leap-seconds.list
+func LoadLeapSecondsFile(path string) error {
+	leaps, expire, err := readLeapSecondsFile(path)
+	if err != nil {
+		return err
+	}
+	SetLeapSeconds(leaps, expire)
+	return nil
+}
+
+func readLeapSecondsFile(path string) (leaps []LeapSecond, expire time.Time, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, expire, err
+	}
+	defer f.Close()
+
+	leaps, expire, err = ParseLeapSecondsList(f)
+	if err != nil {
+		return nil, expire, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return leaps, expire, nil
+}
+
+// ParseLeapSecondsList reads the NTP timestamp and TAI - UTC columns and the
+// "#@" expiration line of a leap-seconds.list file
+func ParseLeapSecondsList(r io.Reader) (leaps []LeapSecond, expire time.Time, err error) {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "#@") {
+			ntp, perr := strconv.ParseInt(strings.TrimSpace(text[2:]), 10, 64)
+			if perr != nil {
+				return nil, expire, fmt.Errorf("line %d: invalid expiration %q", line, text)
+			}
+			expire = time.Unix(ntp-ntpUnixOffset, 0).UTC()
+			continue
+		}
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, expire, fmt.Errorf("line %d: expected an NTP timestamp and TAI - UTC offset", line)
+		}
+		ntp, perr := strconv.ParseInt(fields[0], 10, 64)
+		if perr != nil {
+			return nil, expire, fmt.Errorf("line %d: invalid NTP timestamp %q", line, fields[0])
+		}
+		offset, perr := strconv.Atoi(fields[1])
+		if perr != nil {
+			return nil, expire, fmt.Errorf("line %d: invalid TAI - UTC offset %q", line, fields[1])
+		}
+		leaps = append(leaps, LeapSecond{Time: time.Unix(ntp-ntpUnixOffset, 0).UTC(), Offset: offset})
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, expire, err
+	}
+	if len(leaps) == 0 {
+		return nil, expire, fmt.Errorf("no leap seconds found")
+	}
+
+	return leaps, expire, nil
+}
+
+// SetLeapSeconds replaces the leap second table and its expiration
+func SetLeapSeconds(leaps []LeapSecond, expire time.Time) {
+	// an explicit table takes precedence over LeapSecondsFile
+	leapSecondsOnce.Do(func() {})
+	setLeapSeconds(leaps, expire)
+}
+
+func setLeapSeconds(leaps []LeapSecond, expire time.Time) {
+	leaps = append([]LeapSecond(nil), leaps...)
+	sort.Slice(leaps, func(i, j int) bool { return leaps[i].Time.Before(leaps[j].Time) })
+
+	leapSecondsMu.Lock()
+	defer leapSecondsMu.Unlock()
+	leapSeconds = leaps
+	if !expire.IsZero() {
+		LeapSecondsExpire = expire
+	}
+}
+
+// LeapSecondsExpired reports whether t is past the expiration of the leap second table
+func LeapSecondsExpired(t time.Time) bool {
+	LeapSeconds()
+	leapSecondsMu.RLock()
+	defer leapSecondsMu.RUnlock()
+	return t.After(LeapSecondsExpire)
+}
+
+// TAIOffset returns TAI - UTC in seconds at the UTC instant t. Before 1972 the
+// difference was not a whole number of seconds and the first offset (10) is returned.
+func TAIOffset(t time.Time) int {
+	leaps := LeapSeconds()
+	for i := len(leaps) - 1; i >= 0; i-- {
+		if !t.Before(leaps[i].Time) {
+			return leaps[i].Offset
+		}
+	}
+	return leaps[0].Offset
+}
+
+// UTCToTAI returns the TAI reading (as a UTC based time.Time) for the UTC instant t
+func UTCToTAI(t time.Time) time.Time {
+	return t.UTC().Add(time.Duration(TAIOffset(t)) * time.Second)
+}
+
+// TAIToUTC converts a TAI reading into UTC. TAI readings during an inserted
+// leap second (23:59:60 UTC) are returned as 23:59:59.
+func TAIToUTC(tai time.Time) time.Time {
+	leaps := LeapSeconds()
+	for i := len(leaps) - 1; i >= 0; i-- {
+		utc := tai.Add(-time.Duration(leaps[i].Offset) * time.Second)
+		if !utc.Before(leaps[i].Time) {
+			return utc.UTC()
+		}
+		if i > 0 && !tai.Add(-time.Duration(leaps[i-1].Offset)*time.Second).Before(leaps[i].Time) {
+			// inside the inserted leap second
+			return leaps[i].Time.Add(-time.Second + time.Duration(tai.Nanosecond()))
+		}
+	}
+	return tai.Add(-time.Duration(leaps[0].Offset) * time.Second).UTC()
+}
+
+// UTCToGPS returns the GPS time reading (as a UTC based time.Time) for the UTC instant t
+func UTCToGPS(t time.Time) time.Time {
+	return UTCToTAI(t).Add(-gpsTAIOffset)
+}
+
+// GPSToUTC converts a GPS time reading into UTC
+func GPSToUTC(gps time.Time) time.Time {
+	return TAIToUTC(gps.Add(gpsTAIOffset))
+}
+
+// GPSSeconds returns the seconds of GPS time elapsed since the GPS epoch for the UTC instant t
+func GPSSeconds(t time.Time) int64 {
+	return UTCToGPS(t).Unix() - GPSEpoch.Unix()
+}
+
+// GPSWeekTOW returns the GPS week number (not rolled over at 1024) and time of week for the UTC instant t
+func GPSWeekTOW(t time.Time) (week int, tow time.Duration) {
+	gps := UTCToGPS(t)
+	sec := gps.Unix() - GPSEpoch.Unix()
+	weeks := sec / secondsPerWeek
+	if sec < 0 && sec%secondsPerWeek != 0 {
+		weeks--
+	}
+	tow = time.Duration(sec-weeks*secondsPerWeek)*time.Second + time.Duration(gps.Nanosecond())
+	return int(weeks), tow
+}
+
+// GPSWeekTOWToTime converts a GPS week number and time of week into UTC
+func GPSWeekTOWToTime(week int, tow time.Duration) time.Time {
+	gps := GPSEpoch.Add(time.Duration(week) * secondsPerWeek * time.Second).Add(tow)
+	return GPSToUTC(gps)
+}
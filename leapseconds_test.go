@@ -0,0 +1,80 @@
+package chronus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTAIAcrossLeapSecond(t *testing.T) {
+	// the leap second 2016-12-31T23:59:60Z took TAI - UTC from 36 to 37
+	before := time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)
+	after := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := TAIOffset(before); got != 36 {
+		t.Errorf("TAIOffset(%s) = %d, want 36", before, got)
+	}
+	if got := TAIOffset(after); got != 37 {
+		t.Errorf("TAIOffset(%s) = %d, want 37", after, got)
+	}
+
+	tests := []struct {
+		tai time.Time
+		utc time.Time
+	}{
+		{time.Date(2017, 1, 1, 0, 0, 35, 0, time.UTC), before},
+		{time.Date(2017, 1, 1, 0, 0, 35, 500000000, time.UTC), before.Add(500 * time.Millisecond)},
+		// 23:59:60 has no time.Time of its own
+		{time.Date(2017, 1, 1, 0, 0, 36, 0, time.UTC), before},
+		{time.Date(2017, 1, 1, 0, 0, 36, 250000000, time.UTC), before.Add(250 * time.Millisecond)},
+		{time.Date(2017, 1, 1, 0, 0, 37, 0, time.UTC), after},
+	}
+	for _, tt := range tests {
+		if got := TAIToUTC(tt.tai); !got.Equal(tt.utc) {
+			t.Errorf("TAIToUTC(%s) = %s, want %s", tt.tai.Format(time.RFC3339Nano), got.Format(time.RFC3339Nano), tt.utc.Format(time.RFC3339Nano))
+		}
+	}
+	for _, utc := range []time.Time{before, after, after.Add(time.Hour)} {
+		if got := TAIToUTC(UTCToTAI(utc)); !got.Equal(utc) {
+			t.Errorf("TAIToUTC(UTCToTAI(%s)) = %s", utc, got)
+		}
+	}
+}
+
+func TestGPSWeekTOW(t *testing.T) {
+	ts := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	week, tow := GPSWeekTOW(ts)
+	if week != 1930 || tow != 18*time.Second {
+		t.Errorf("GPSWeekTOW(%s) = %d, %s, want 1930, 18s", ts, week, tow)
+	}
+	if got := GPSWeekTOWToTime(week, tow); !got.Equal(ts) {
+		t.Errorf("GPSWeekTOWToTime(%d, %s) = %s, want %s", week, tow, got, ts)
+	}
+	if got := GPSSeconds(GPSEpoch); got != 0 {
+		t.Errorf("GPSSeconds(GPSEpoch) = %d, want 0", got)
+	}
+}
+
+func TestParseLeapSecondsList(t *testing.T) {
+	list := `# a trimmed leap-seconds.list
+#@	4007404800
+3644697600	36	# 1 Jul 2015
+3692217600	37	# 1 Jan 2017
+`
+	leaps, expire, err := ParseLeapSecondsList(strings.NewReader(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC); !expire.Equal(want) {
+		t.Errorf("expire = %s, want %s", expire, want)
+	}
+	if len(leaps) != 2 || !leaps[1].Time.Equal(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)) || leaps[1].Offset != 37 {
+		t.Errorf("leaps = %v", leaps)
+	}
+
+	if _, _, err := ParseLeapSecondsList(strings.NewReader("3692217600\n")); err == nil {
+		t.Error("ParseLeapSecondsList without an offset did not fail")
+	}
+	if _, _, err := ParseLeapSecondsList(strings.NewReader("# nothing\n")); err == nil {
+		t.Error("ParseLeapSecondsList without entries did not fail")
+	}
+}
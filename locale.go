@@ -0,0 +1,465 @@
+package chronus
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// LocaleCode is the preferred locale used when parsing localized date-time strings
+	LocaleCode = os.Getenv("CHRONUS_LOCALE")
+
+	locales   = map[string]*Locale{}
+	localesMu sync.RWMutex
+
+	englishMonths       = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	englishMonthsAbbr   = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	englishWeekdays     = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	englishWeekdaysAbbr = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+	reDottedDate    = regexp.MustCompile(`\d\.\d{1,2}\.\d`)
+	reLocalizedYear = regexp.MustCompile(`(^|\D)\d{4}(\D|$)`)
+
+	localeTimeSuffixes = []string{"", " 15:04", " 15:04:05", ", 15:04", ", 15:04:05", " 15:04 MST", " 15:04:05 MST", " 15:04:05 -0700"}
+)
+
+// Locale is a pack of localized month and weekday names along with the Go
+// layouts (written with the English reference names) that the locale commonly
+// uses. Weekdays are indexed like time.Weekday, starting with Sunday.
+type Locale struct {
+	Code           string
+	Name           string
+	Months         [12]string
+	MonthsAbbr     [12]string
+	Weekdays       [7]string
+	WeekdaysAbbr   [7]string
+	Aliases        map[string]string // extra localized fragments and their English replacements
+	Layouts        []string          // layouts tried by Parse after names are translated to English
+	DateTimeLayout string            // default layout for Format output
+	NoWordBreaks   bool              // names are not separated by spaces (e.g. Japanese)
+
+	once     sync.Once
+	names    map[string]localeName
+	aliases  *strings.Replacer // Aliases, longest first
+	weekdays *strings.Replacer // full weekday names for NoWordBreaks
+}
+
+type localeName struct {
+	english string
+	abbr    bool
+}
+
+func init() {
+	RegisterLocale(&Locale{
+		Code:           "en",
+		Name:           "English",
+		Months:         englishMonths,
+		MonthsAbbr:     englishMonthsAbbr,
+		Weekdays:       englishWeekdays,
+		WeekdaysAbbr:   englishWeekdaysAbbr,
+		Layouts:        expandLayouts([]string{"", "Monday, ", "Monday "}, []string{"2 January 2006", "January 2, 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday, 2 January 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "fr",
+		Name:           "Français",
+		Months:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthsAbbr:     [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		WeekdaysAbbr:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		Aliases:        map[string]string{" à ": " ", "1er ": "1 "},
+		Layouts:        expandLayouts([]string{"", "Monday ", "Monday, ", "Mon ", "Mon, "}, []string{"2 January 2006", "2 Jan 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday 2 January 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "de",
+		Name:           "Deutsch",
+		Months:         [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsAbbr:     [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Weekdays:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		WeekdaysAbbr:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		Aliases:        map[string]string{"Jänner": "Januar", " um ": " ", " Uhr": ""},
+		Layouts:        expandLayouts([]string{"", "Monday, ", "Monday ", "Mon, "}, []string{"2. January 2006", "2. Jan 2006", "2 January 2006", "02.01.2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday, 2. January 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "es",
+		Name:           "Español",
+		Months:         [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		MonthsAbbr:     [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		Weekdays:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		WeekdaysAbbr:   [7]string{"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."},
+		Aliases:        map[string]string{"setiembre": "septiembre", " a las ": " "},
+		Layouts:        expandLayouts([]string{"", "Monday, ", "Monday "}, []string{"2 de January de 2006", "2 January 2006", "2 Jan 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday, 2 de January de 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "it",
+		Name:           "Italiano",
+		Months:         [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		MonthsAbbr:     [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:       [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		WeekdaysAbbr:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		Aliases:        map[string]string{" alle ": " ", " ore ": " "},
+		Layouts:        expandLayouts([]string{"", "Monday ", "Monday, "}, []string{"2 January 2006", "2 Jan 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday 2 January 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "pt",
+		Name:           "Português",
+		Months:         [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		MonthsAbbr:     [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		Weekdays:       [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		WeekdaysAbbr:   [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		Aliases:        map[string]string{" às ": " "},
+		Layouts:        expandLayouts([]string{"", "Monday, ", "Monday "}, []string{"2 de January de 2006", "2 January 2006", "2 Jan 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday, 2 de January de 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:           "nl",
+		Name:           "Nederlands",
+		Months:         [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		MonthsAbbr:     [12]string{"jan.", "feb.", "mrt.", "apr.", "mei", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "dec."},
+		Weekdays:       [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		WeekdaysAbbr:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		Aliases:        map[string]string{" om ": " "},
+		Layouts:        expandLayouts([]string{"", "Monday ", "Monday, "}, []string{"2 January 2006", "2 Jan 2006"}, localeTimeSuffixes),
+		DateTimeLayout: "Monday 2 January 2006 15:04:05",
+	})
+	RegisterLocale(&Locale{
+		Code:         "ja",
+		Name:         "日本語",
+		Months:       [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		MonthsAbbr:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:     [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		WeekdaysAbbr: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		Aliases:      japaneseAliases(),
+		Layouts: expandLayouts(
+			[]string{""},
+			[]string{"2006年1月2日", "2006年1月2日 Monday", "2006年1月2日Monday", "2006年1月2日(Mon)", "2006年1月2日 (Mon)"},
+			[]string{"", " 15:04", " 15:04:05", " 15時4分", " 15時4分5秒", " PM3時4分", " PM3:04"},
+		),
+		DateTimeLayout: "2006年1月2日(Mon) 15:04:05",
+		NoWordBreaks:   true,
+	})
+}
+
+// japaneseAliases maps the parenthesized weekday abbreviations (in both ASCII
+// and full width parentheses) and the AM/PM markers to English
+func japaneseAliases() map[string]string {
+	aliases := map[string]string{"午前": "AM", "午後": "PM"}
+	for i, abbr := range [7]string{"日", "月", "火", "水", "木", "金", "土"} {
+		aliases["("+abbr+")"] = "(" + englishWeekdaysAbbr[i] + ")"
+		aliases["（"+abbr+"）"] = "(" + englishWeekdaysAbbr[i] + ")"
+	}
+	return aliases
+}
+
+// expandLayouts combines every prefix, date, and suffix into a list of layouts
+func expandLayouts(prefixes, dates, suffixes []string) (layouts []string) {
+	for _, prefix := range prefixes {
+		for _, date := range dates {
+			for _, suffix := range suffixes {
+				layouts = append(layouts, prefix+date+suffix)
+			}
+		}
+	}
+	return layouts
+}
+
+// RegisterLocale adds (or replaces) a locale pack keyed by its code
+func RegisterLocale(l *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(l.Code)] = l
+}
+
+// GetLocale returns the registered locale pack for the code (e.g. "fr" or "de-AT")
+func GetLocale(code string) (l *Locale, err error) {
+	code = strings.ToLower(strings.Replace(code, "_", "-", -1))
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	if l, ok := locales[code]; ok {
+		return l, nil
+	}
+	if i := strings.Index(code, "-"); i > 0 {
+		if l, ok := locales[code[:i]]; ok {
+			return l, nil
+		}
+	}
+
+	return nil, fmt.Errorf("locale %q not registered", code)
+}
+
+// LocaleCodes returns the codes of all registered locales in sorted order
+func LocaleCodes() (codes []string) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// FormatLocale formats t with a Go layout using the month and weekday names of the locale
+func FormatLocale(t time.Time, layout, code string) (string, error) {
+	l, err := GetLocale(code)
+	if err != nil {
+		return "", err
+	}
+	return l.Format(t, layout), nil
+}
+
+// ParseLocale parses a date-time string written in the given locale
+func ParseLocale(dtz, code string) (t time.Time, err error) {
+	l, err := GetLocale(code)
+	if err != nil {
+		return t, err
+	}
+	return l.Parse(dtz)
+}
+
+// ParseLocalized tries the preferred LocaleCode first and then every other
+// registered locale until one of them parses the date-time string
+func ParseLocalized(dtz string) (t time.Time, code string, err error) {
+	codes := LocaleCodes()
+	if len(LocaleCode) > 0 {
+		if l, lerr := GetLocale(LocaleCode); lerr == nil {
+			codes = append([]string{strings.ToLower(l.Code)}, codes...)
+		}
+	}
+
+	for _, code = range codes {
+		t, err = ParseLocale(dtz, code)
+		if err == nil {
+			DebugPrintf("chronus.ParseLocalized() | dtz: %q | locale: %q\n", dtz, code)
+			return t, code, nil
+		}
+	}
+
+	return t, "", fmt.Errorf("no locale could parse %q", dtz)
+}
+
+// Format formats t with a Go layout, replacing the English month and weekday
+// names the layout produces with the localized names
+func (l *Locale) Format(t time.Time, layout string) string {
+	var b strings.Builder
+
+	for len(layout) > 0 {
+		i, token := nextNameToken(layout)
+		if i < 0 {
+			b.WriteString(t.Format(layout))
+			break
+		}
+		if i > 0 {
+			b.WriteString(t.Format(layout[:i]))
+		}
+
+		name := ""
+		switch token {
+		case "January":
+			name = l.Months[t.Month()-1]
+		case "Jan":
+			name = l.MonthsAbbr[t.Month()-1]
+		case "Monday":
+			name = l.Weekdays[t.Weekday()]
+		case "Mon":
+			name = l.WeekdaysAbbr[t.Weekday()]
+		}
+		if len(name) == 0 {
+			name = t.Format(token)
+		}
+		b.WriteString(name)
+
+		layout = layout[i+len(token):]
+	}
+
+	return b.String()
+}
+
+// Parse translates the localized names in dtz to English and tries each of the
+// locale layouts in turn
+func (l *Locale) Parse(dtz string) (t time.Time, err error) {
+	english := l.ToEnglish(strings.TrimSpace(dtz))
+	DebugPrintf("chronus.Locale.Parse() | locale: %q | dtz: %q | english: %q\n", l.Code, dtz, english)
+
+	for _, layout := range l.Layouts {
+		t, err = time.ParseInLocation(layout, english, defaultLocation())
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return t, fmt.Errorf("unable to parse %q as a %s date", dtz, l.Code)
+}
+
+// ToEnglish replaces the localized month and weekday names in s with the
+// English names understood by the Go time package. Single character
+// abbreviations are ignored as they are too ambiguous on their own.
+func (l *Locale) ToEnglish(s string) string {
+	l.once.Do(l.buildNames)
+
+	if l.aliases != nil {
+		s = l.aliases.Replace(s)
+	}
+	if l.NoWordBreaks {
+		// only full weekday names can be picked out without word breaks
+		return l.weekdays.Replace(s)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) {
+			b.WriteRune(r)
+			i += size
+			continue
+		}
+
+		// collect a word, allowing inner hyphens (e.g. "segunda-feira")
+		j := i
+		for j < len(s) {
+			r, size = utf8.DecodeRuneInString(s[j:])
+			if unicode.IsLetter(r) {
+				j += size
+				continue
+			}
+			if r == '-' && j+size < len(s) {
+				next, _ := utf8.DecodeRuneInString(s[j+size:])
+				if unicode.IsLetter(next) {
+					j += size
+					continue
+				}
+			}
+			break
+		}
+
+		word := s[i:j]
+		if n, ok := l.names[strings.ToLower(word)]; ok {
+			b.WriteString(n.english)
+			if n.abbr && j < len(s) && s[j] == '.' {
+				j++
+			}
+		} else {
+			b.WriteString(word)
+		}
+		i = j
+	}
+
+	return b.String()
+}
+
+// buildNames indexes the names and builds the replacers of the locale the first
+// time it is used, so later changes to its fields are not seen
+func (l *Locale) buildNames() {
+	if len(l.Aliases) > 0 {
+		keys := make([]string, 0, len(l.Aliases))
+		for k := range l.Aliases {
+			keys = append(keys, k)
+		}
+		sortLongestFirst(keys)
+		pairs := make([]string, 0, len(keys)*2)
+		for _, k := range keys {
+			pairs = append(pairs, k, l.Aliases[k])
+		}
+		l.aliases = strings.NewReplacer(pairs...)
+	}
+
+	pairs := make([]string, 0, len(l.Weekdays)*2)
+	for i, name := range l.Weekdays {
+		if len(name) > 0 {
+			pairs = append(pairs, name, englishWeekdays[i])
+		}
+	}
+	l.weekdays = strings.NewReplacer(pairs...)
+
+	l.names = map[string]localeName{}
+	add := func(name, english string, abbr bool) {
+		key := strings.ToLower(strings.TrimSuffix(name, "."))
+		if utf8.RuneCountInString(key) < 2 {
+			return
+		}
+		if _, ok := l.names[key]; ok {
+			return
+		}
+		l.names[key] = localeName{english: english, abbr: abbr}
+	}
+
+	// month names come first so they win any collision (e.g. Spanish "mar.")
+	for i := range l.Months {
+		add(l.Months[i], englishMonths[i], false)
+		add(l.MonthsAbbr[i], englishMonthsAbbr[i], true)
+	}
+	for i := range l.Weekdays {
+		add(l.Weekdays[i], englishWeekdays[i], false)
+		add(l.WeekdaysAbbr[i], englishWeekdaysAbbr[i], true)
+	}
+}
+
+// isDateName reports whether word is a month or weekday name (or abbreviation)
+// in any registered locale, ignoring case and a trailing period or comma
+func isDateName(word string) bool {
+	key := strings.ToLower(strings.TrimRight(word, ".,"))
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	for _, l := range locales {
+		l.once.Do(l.buildNames)
+		if _, ok := l.names[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mayBeLocalized reports whether ParseLocalized could parse s, which needs a
+// four digit year and a month or weekday name, non-ASCII text (such as
+// Japanese), or a dotted numeric date (such as the German 08.03.2021)
+func mayBeLocalized(s string) bool {
+	if !reLocalizedYear.MatchString(s) {
+		return false
+	}
+	if reDottedDate.MatchString(s) {
+		return true
+	}
+	for _, r := range s {
+		if r >= utf8.RuneSelf {
+			return true
+		}
+	}
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if isDateName(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextNameToken finds the first month or weekday name token in a Go layout
+func nextNameToken(layout string) (index int, token string) {
+	index = -1
+	for _, tok := range []string{"January", "Monday", "Jan", "Mon"} {
+		i := strings.Index(layout, tok)
+		if i >= 0 && (index < 0 || i < index) {
+			index, token = i, tok
+		}
+	}
+	return index, token
+}
+
+func sortLongestFirst(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+}
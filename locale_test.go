@@ -0,0 +1,46 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleToEnglish(t *testing.T) {
+	tests := []struct {
+		code, s, want string
+	}{
+		{"fr", "lundi 8 mars 2021 à 16:06", "Monday 8 March 2021 16:06"},
+		{"de", "8. Jänner 2021 um 16:06 Uhr", "8. January 2021 16:06"},
+		{"es", "8 de setiembre de 2021", "8 de September de 2021"},
+	}
+	for _, tt := range tests {
+		l, err := GetLocale(tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the cached replacers give the same result every time
+		for i := 0; i < 2; i++ {
+			if got := l.ToEnglish(tt.s); got != tt.want {
+				t.Errorf("%s ToEnglish(%q) = %q, want %q", tt.code, tt.s, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	want := time.Date(2021, 3, 8, 16, 6, 0, 0, time.UTC)
+	inputs := map[string]string{
+		"fr": "8 mars 2021 16:06",
+		"de": "8. März 2021 16:06",
+		"ja": "2021年3月8日(月) 16:06",
+	}
+	for code, dtz := range inputs {
+		got, err := ParseLocale(dtz, code)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseLocale(%q, %s) = %s, %v, want %s", dtz, code, got, err, want)
+		}
+	}
+	if _, err := GetLocale("xx"); err == nil {
+		t.Error(`GetLocale("xx") did not fail`)
+	}
+}
@@ -0,0 +1,164 @@
+package chronus
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// leadingMaxFields is the most whitespace separated fields a leading timestamp
+// may span, such as "Mon, 08 Mar 2021 16:06:34 -0700 (PST)"
+const leadingMaxFields = 8
+
+// leadingClosing is the punctuation that may follow a timestamp such as the
+// colon in "2021-03-08T16:06:34Z: message"
+const leadingClosing = ":;,|.!?)]}\"'`"
+
+// leadingZones are the zone abbreviations that are UTC, any other unknown
+// abbreviation Go parses as a zero offset is more likely a log level or word
+var leadingZones = map[string]bool{"UTC": true, "GMT": true, "UT": true, "Z": true}
+
+// ParseLeading finds the timestamp at the start of a log line and returns it
+// with the byte offset where it ends, so line[end:] is the rest of the line.
+// The longest run of up to 8 fields GetFormat can parse wins. Syslog, CLF, ELB,
+// and W3C lines report just their timestamp (CLF without the brackets). To
+// avoid reading words as times, relative expressions, UNIX timestamps with
+// fewer than 9 digits, and cookie dates are not matched.
+func ParseLeading(line string) (p Parsed, start, end int, err error) {
+	var ends []int
+	inField := false
+	for i, r := range line {
+		if unicode.IsSpace(r) {
+			if inField {
+				ends = append(ends, i)
+			}
+			inField = false
+		} else {
+			inField = true
+		}
+		if len(ends) == leadingMaxFields {
+			break
+		}
+	}
+	if inField && len(ends) < leadingMaxFields {
+		ends = append(ends, len(line))
+	}
+
+	for i := len(ends) - 1; i >= 0; i-- {
+		candidates := []int{ends[i]}
+		if trimmed := strings.TrimRight(line[:ends[i]], leadingClosing); len(trimmed) > 0 && len(trimmed) < ends[i] {
+			// 2021-03-08T16:06:34Z: message or (at 2021-03-08T16:06:34Z).
+			candidates = append(candidates, len(trimmed))
+		}
+		for _, e := range candidates {
+			s := line[:e]
+			if s != strings.TrimSpace(s) {
+				continue
+			}
+			format, tzloc := GetFormat(s)
+			if format == SyslogRFC3164 && GetSyslogFormat(line[:ends[len(ends)-1]]) != format {
+				// the year after Mar  8 16:06:34 CET 2021 is past this candidate
+				continue
+			}
+			if start, end, ok := logTimestampSpan(format, s); ok {
+				if p, err = ParseDetailed(line[start:end]); err == nil {
+					return p, start, end, nil
+				}
+				continue
+			} else if isLogLineFormat(format) {
+				// the line parsers ignore the rest of the line, try a shorter candidate
+				continue
+			}
+			if format == "" && !mayBeLocalized(s) {
+				// only the localized names fallback could parse it
+				continue
+			}
+			if !leadingFormatAllowed(format, s) {
+				continue
+			}
+			if p, err = parseDetailed(s, format, tzloc); err == nil && leadingParsedAllowed(p, s) {
+				return p, 0, e, nil
+			}
+		}
+	}
+
+	return p, 0, 0, fmt.Errorf("no leading timestamp in %q", line)
+}
+
+// leadingFormatAllowed rejects formats that commonly match ordinary words or numbers
+func leadingFormatAllowed(format, s string) bool {
+	switch format {
+	case RelativeFormat:
+		return false
+	case UnixTimeStamp, UnixTimeStampFloat:
+		return len(strings.SplitN(strings.TrimLeft(s, "+-"), ".", 2)[0]) >= 9
+	}
+	return true
+}
+
+// leadingParsedAllowed rejects the lenient cookie date fallback for strings
+// GetFormat did not recognize, and a trailing word Go accepted as an unknown
+// zone abbreviation such as the INFO or ERR level after a zone-less timestamp
+func leadingParsedAllowed(p Parsed, s string) bool {
+	if p.Format == CookieDate {
+		return false
+	}
+	fields := strings.Fields(s)
+	last := fields[len(fields)-1]
+	name, offset := p.Time.Zone()
+	return !(name == last && offset == 0 && !leadingZones[last])
+}
+
+// isLogLineFormat reports whether format is one of the line oriented detectors
+func isLogLineFormat(format string) bool {
+	switch format {
+	case SyslogRFC3164, SyslogRFC5424, CommonLogFormat, CommonLogTime, ELBTimestamp, W3CDateTime:
+		return true
+	}
+	return false
+}
+
+// logTimestampSpan returns where the timestamp is in a line matched by one of
+// the line oriented detectors (syslog, CLF, ELB, W3C). A W3C date and time
+// followed by an offset or zone such as "-0700" is not a span, so a shorter
+// candidate parses the offset with it rather than leaving it in the line.
+func logTimestampSpan(format, line string) (start, end int, ok bool) {
+	var m []int
+	switch format {
+	case SyslogRFC3164:
+		if m = reSyslog3164.FindStringSubmatchIndex(line); m != nil {
+			// from the month to the seconds or their fraction
+			start, end = m[4], m[13]
+			if m[14] >= 0 {
+				end = m[15]
+			}
+		}
+	case SyslogRFC5424:
+		if m = reSyslog5424.FindStringSubmatchIndex(line); m != nil {
+			start, end = m[2], m[3]
+		}
+	case CommonLogFormat, CommonLogTime:
+		if m = reCommonLog.FindStringSubmatchIndex(line); m != nil {
+			start, end = m[2], m[3]
+		}
+	case ELBTimestamp:
+		if m = reELB.FindStringSubmatchIndex(line); m != nil {
+			start, end = m[6], m[7]
+		}
+	case W3CDateTime:
+		if m = reW3C.FindStringSubmatchIndex(line); m != nil {
+			start, end = m[2], m[5]
+		}
+	}
+
+	if m == nil {
+		return 0, 0, false
+	}
+	if format == W3CDateTime {
+		if next := strings.Fields(line[end:]); len(next) > 0 && reW3CZone.MatchString(next[0]) {
+			return 0, 0, false
+		}
+	}
+
+	return start, end, true
+}
@@ -0,0 +1,54 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLeading(t *testing.T) {
+	defer func(loc *time.Location, ref time.Time) { DefaultLocation, ReferenceTime = loc, ref }(DefaultLocation, ReferenceTime)
+	DefaultLocation = time.UTC
+	ReferenceTime = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		line string
+		want string // RFC 3339 time, empty when no timestamp should be found
+		span string // line[start:end]
+	}{
+		{"2021-03-08T16:06:34.123-07:00 INFO api started", "2021-03-08T16:06:34.123-07:00", "2021-03-08T16:06:34.123-07:00"},
+		{"2021-03-08T23:07:10Z: colon after", "2021-03-08T23:07:10Z", "2021-03-08T23:07:10Z"},
+		{"Mar  8 23:07:01 web01 nginx[123]: upstream timeout", "2021-03-08T23:07:01Z", "Mar  8 23:07:01"},
+		{"<34>1 2021-03-08T23:07:02.5Z host app - - hello", "2021-03-08T23:07:02.5Z", "2021-03-08T23:07:02.5Z"},
+		{`[08/Mar/2021:16:07:03 -0700] "GET / HTTP/1.1" 200`, "2021-03-08T16:07:03-07:00", "08/Mar/2021:16:07:03 -0700"},
+		{"h2 2021-03-08T23:07:06.186641Z app/my-lb 1.2.3.4:80", "2021-03-08T23:07:06.186641Z", "2021-03-08T23:07:06.186641Z"},
+		{"2021-03-08 23:07:07 W3SVC1 10.0.0.1 GET /", "2021-03-08T23:07:07Z", "2021-03-08 23:07:07"},
+		{"2021-03-08 16:06:34 -0700 INFO started", "2021-03-08T16:06:34-07:00", "2021-03-08 16:06:34 -0700"},
+		{"2021-03-08 23:07:04 ERR disk full", "2021-03-08T23:07:04Z", "2021-03-08 23:07:04"},
+		{"1615244825 worker tick", "2021-03-08T23:07:05Z", "1615244825"},
+		{"Mon, 08 Mar 2021 16:07:08 -0700 mail received", "2021-03-08T16:07:08-07:00", "Mon, 08 Mar 2021 16:07:08 -0700"},
+		{"08.03.2021 16:06 Uhr", "2021-03-08T16:06:00Z", "08.03.2021 16:06 Uhr"},
+		{"March 9, 2021 followup", "2021-03-09T00:00:00Z", "March 9, 2021"},
+		{"200 OK plain line", "", ""},
+		{"now is the time", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		p, start, end, err := ParseLeading(tt.line)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseLeading(%q) = %s at %d-%d, want no timestamp", tt.line, p.Time.Format(time.RFC3339Nano), start, end)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLeading(%q) error: %s", tt.line, err)
+			continue
+		}
+		if got := p.Time.Format(time.RFC3339Nano); got != tt.want {
+			t.Errorf("ParseLeading(%q) = %s, want %s", tt.line, got, tt.want)
+		}
+		if got := tt.line[start:end]; got != tt.span {
+			t.Errorf("ParseLeading(%q) span = %q, want %q", tt.line, got, tt.span)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NTPTimestamp is the 64 bit NTP timestamp format: 32 bits of seconds since the
+// start of the NTP era and 32 bits of fraction. Era 0 began 1900-01-01 UTC and
+// era 1 begins 2036-02-07 06:28:16 UTC.
+type NTPTimestamp uint64
+
+// NTPShort is the 32 bit NTP short format used for root delay and dispersion:
+// 16 bits of seconds and 16 bits of fraction
+type NTPShort uint32
+
+const (
+	// ntpEraSeconds is the length of an NTP era
+	ntpEraSeconds = 1 << 32
+)
+
+var reNTPHex = regexp.MustCompile(`^([0-9a-fA-F]{8})\.([0-9a-fA-F]{8})$`) // e3f1a2b4.1f9a6c00 as ntpq prints it
+
+// NewNTPTimestamp builds a timestamp from its seconds and fraction fields
+func NewNTPTimestamp(seconds, fraction uint32) NTPTimestamp {
+	return NTPTimestamp(uint64(seconds)<<32 | uint64(fraction))
+}
+
+// NTPTimestampFromTime converts t into an NTP timestamp, discarding the era
+func NTPTimestampFromTime(t time.Time) NTPTimestamp {
+	_, ts := NTPEra(t)
+	return ts
+}
+
+// NTPEra returns the NTP era containing t and the timestamp within that era
+func NTPEra(t time.Time) (era int, ts NTPTimestamp) {
+	sec := t.Unix() + ntpUnixOffset
+	era = int(sec / ntpEraSeconds)
+	if sec < 0 && sec%ntpEraSeconds != 0 {
+		era--
+	}
+	offset := sec - int64(era)*ntpEraSeconds
+	return era, NewNTPTimestamp(uint32(offset), nanosecondsToNTPFraction(uint64(t.Nanosecond())))
+}
+
+// NTPEraToTime converts an NTP timestamp in the given era into Go time.Time
+func NTPEraToTime(era int, ts NTPTimestamp) time.Time {
+	sec := int64(era)*ntpEraSeconds + int64(ts.Seconds()) - ntpUnixOffset
+	return time.Unix(sec, ts.nanoseconds())
+}
+
+// Seconds returns the seconds field of the timestamp
+func (ts NTPTimestamp) Seconds() uint32 {
+	return uint32(ts >> 32)
+}
+
+// Fraction returns the fraction field of the timestamp in units of 2^-32 seconds
+func (ts NTPTimestamp) Fraction() uint32 {
+	return uint32(ts)
+}
+
+// nanosecondsToNTPFraction converts nanoseconds (less than a second) into a rounded
+// fraction in units of 2^-32 seconds
+func nanosecondsToNTPFraction(ns uint64) uint32 {
+	return uint32((ns<<32 + 5e8) / 1e9)
+}
+
+// nanoseconds converts the fraction into rounded nanoseconds. Fractions that
+// would round up to a whole second (0xfffffffe and above) are truncated to
+// 999999999 so the seconds field is never changed.
+func (ts NTPTimestamp) nanoseconds() int64 {
+	ns := int64((uint64(ts.Fraction())*1e9 + 1<<31) >> 32)
+	if ns >= 1e9 {
+		return 1e9 - 1
+	}
+	return ns
+}
+
+// Time converts the timestamp into Go time.Time using the RFC 4330 rule: when
+// the most significant bit is set the time is in era 0 (1968-2036), otherwise
+// it is in era 1 (2036-2104)
+func (ts NTPTimestamp) Time() time.Time {
+	if ts.Seconds()&0x80000000 != 0 {
+		return NTPEraToTime(0, ts)
+	}
+	return NTPEraToTime(1, ts)
+}
+
+// TimeNear converts the timestamp into Go time.Time choosing the era that places it closest to pivot
+func (ts NTPTimestamp) TimeNear(pivot time.Time) time.Time {
+	era, _ := NTPEra(pivot)
+	best := NTPEraToTime(era, ts)
+	for _, e := range []int{era - 1, era + 1} {
+		t := NTPEraToTime(e, ts)
+		if absDuration(t.Sub(pivot)) < absDuration(best.Sub(pivot)) {
+			best = t
+		}
+	}
+	return best
+}
+
+// Hex returns the timestamp as hexadecimal seconds and fraction such as e3f1a2b4.1f9a6c00
+func (ts NTPTimestamp) Hex() string {
+	return fmt.Sprintf("%08x.%08x", ts.Seconds(), ts.Fraction())
+}
+
+// String returns the timestamp as decimal seconds with nanoseconds as tcpdump prints it
+func (ts NTPTimestamp) String() string {
+	return fmt.Sprintf("%d.%09d", ts.Seconds(), ts.nanoseconds())
+}
+
+// ParseNTPTimestamp parses an NTP timestamp in any of the common notations:
+// 0x prefixed hexadecimal (0xe3f1a2b41f9a6c00, or 0xe3f1a2b4 for seconds only),
+// hexadecimal seconds and fraction as ntpq prints (e3f1a2b4.1f9a6c00), or decimal
+// seconds with an optional fraction as tcpdump prints (3824370610.123456789)
+func ParseNTPTimestamp(s string) (ts NTPTimestamp, err error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(strings.ToLower(s), "0x") {
+		digits := s[2:]
+		u, err := strconv.ParseUint(digits, 16, 64)
+		if err != nil || len(digits) > 16 {
+			return ts, fmt.Errorf("invalid hexadecimal NTP timestamp %q", s)
+		}
+		if len(digits) <= 8 {
+			u <<= 32
+		}
+		return NTPTimestamp(u), nil
+	}
+
+	if matches := reNTPHex.FindStringSubmatch(s); matches != nil {
+		sec, _ := strconv.ParseUint(matches[1], 16, 32)
+		frac, _ := strconv.ParseUint(matches[2], 16, 32)
+		return NewNTPTimestamp(uint32(sec), uint32(frac)), nil
+	}
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	sec, err := strconv.ParseUint(whole, 10, 32)
+	if err != nil || strings.Trim(frac, "0123456789") != "" {
+		return ts, fmt.Errorf("invalid NTP timestamp %q", s)
+	}
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	ns, _ := strconv.ParseUint(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	return NewNTPTimestamp(uint32(sec), nanosecondsToNTPFraction(ns)), nil
+}
+
+// ParseNTPTime parses an NTP timestamp (see ParseNTPTimestamp) into Go time.Time
+func ParseNTPTime(s string) (t time.Time, err error) {
+	ts, err := ParseNTPTimestamp(s)
+	if err != nil {
+		return t, err
+	}
+	return ts.Time(), nil
+}
+
+// NTPShortFromDuration converts a duration into the NTP short format. Negative
+// durations are returned as 0.
+func NTPShortFromDuration(d time.Duration) NTPShort {
+	if d < 0 {
+		return 0
+	}
+	return NTPShort((uint64(d)<<16 + 5e8) / 1e9)
+}
+
+// Duration converts the NTP short format into a time.Duration
+func (s NTPShort) Duration() time.Duration {
+	return time.Duration((uint64(s)*1e9 + 1<<15) >> 16)
+}
+
+// String returns the short format as decimal seconds
+func (s NTPShort) String() string {
+	return strconv.FormatFloat(s.Duration().Seconds(), 'f', -1, 64)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,69 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNTPTimestampString(t *testing.T) {
+	tests := []struct {
+		ts   NTPTimestamp
+		want string
+	}{
+		{NewNTPTimestamp(3824370610, 0), "3824370610.000000000"},
+		{NewNTPTimestamp(3824370610, 0x80000000), "3824370610.500000000"},
+		{NewNTPTimestamp(3824370610, 0xfffffffd), "3824370610.999999999"},
+		{NewNTPTimestamp(3824370610, 0xfffffffe), "3824370610.999999999"},
+		{NewNTPTimestamp(3824370610, 0xffffffff), "3824370610.999999999"},
+	}
+	for _, tt := range tests {
+		if got := tt.ts.String(); got != tt.want {
+			t.Errorf("%s.String() = %s, want %s", tt.ts.Hex(), got, tt.want)
+		}
+		if ns := tt.ts.Time().Nanosecond(); ns >= 1e9 {
+			t.Errorf("%s.Time() nanoseconds = %d", tt.ts.Hex(), ns)
+		}
+	}
+}
+
+func TestParseNTPTimestamp(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"0xe3f0ca0a", "2021-03-08T16:06:34Z"},
+		{"0xe3f0ca0a80000000", "2021-03-08T16:06:34.5Z"},
+		{"e3f0ca0a.80000000", "2021-03-08T16:06:34.5Z"},
+		{"3824208394.25", "2021-03-08T16:06:34.25Z"},
+		{"0x00000000", "2036-02-07T06:28:16Z"},
+	}
+	for _, tt := range tests {
+		got, err := ParseNTPTime(tt.s)
+		if err != nil {
+			t.Errorf("ParseNTPTime(%q) error: %s", tt.s, err)
+			continue
+		}
+		if s := got.UTC().Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseNTPTime(%q) = %s, want %s", tt.s, s, tt.want)
+		}
+	}
+
+	for _, s := range []string{"0x", "e3f1a2b4.zz", "3824370612.x", "99999999999"} {
+		if _, err := ParseNTPTimestamp(s); err == nil {
+			t.Errorf("ParseNTPTimestamp(%q) should fail", s)
+		}
+	}
+}
+
+func TestNTPRoundTrip(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Date(2021, 3, 8, 16, 6, 34, 123456789, time.UTC),
+		time.Date(2040, 1, 1, 0, 0, 0, 999999999, time.UTC),
+		time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC),
+	} {
+		got := NTPTimestampFromTime(want).TimeNear(want)
+		if d := absDuration(got.Sub(want)); d > time.Nanosecond {
+			t.Errorf("NTP round trip of %s = %s", want.Format(time.RFC3339Nano), got.Format(time.RFC3339Nano))
+		}
+	}
+}
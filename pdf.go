@@ -0,0 +1,93 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PDFDate is to denote the format is a PDF date string such as "D:20210308160634-07'00'" (PDF 32000-1 section 7.9.4)
+	PDFDate = "PDF Date"
+
+	regExPDFDate = `^(D:)?(\d{4})(\d\d)?(\d\d)?(\d\d)?(\d\d)?(\d\d)?(([+-])(\d\d)'?((\d\d)'?)?|Z(00'?(00'?)?)?)?$`
+)
+
+var rePDFDate = regexp.MustCompile(regExPDFDate)
+
+// GetPDFDateFormat determines if the provided string is a PDF date. The D:
+// prefix is optional when parsing but needed to tell it apart from other digits.
+func GetPDFDateFormat(dtz string) (format string) {
+	if strings.HasPrefix(dtz, "D:") && rePDFDate.MatchString(dtz) {
+		format = PDFDate
+		DebugPrintf("chronus.GetPDFDateFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// ParsePDFDate parses a PDF date D:YYYY[MM[DD[HH[mm[SS[O[HH'[mm']]]]]]]]
+// accepting the common quirks: no D: prefix, no trailing apostrophe (PDF 2.0),
+// no offset minutes, and Z00'00'. Missing fields default to the start of the
+// period and without an offset the time is in DefaultLocation (nil is UTC).
+func ParsePDFDate(dtz string) (t time.Time, err error) {
+	m := rePDFDate.FindStringSubmatch(strings.TrimSpace(dtz))
+	if m == nil {
+		return t, fmt.Errorf("invalid PDF date %q", dtz)
+	}
+
+	field := func(s string, def int) int {
+		if s == "" {
+			return def
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	year := field(m[2], 0)
+	month := field(m[3], 1)
+	day := field(m[4], 1)
+	hour := field(m[5], 0)
+	minute := field(m[6], 0)
+	second := field(m[7], 0)
+
+	loc := defaultLocation()
+	switch {
+	case strings.HasPrefix(m[8], "Z"):
+		loc = time.UTC
+	case len(m[9]) > 0:
+		hh, mm := field(m[10], 0), field(m[12], 0)
+		if hh > 23 || mm > 59 {
+			return t, fmt.Errorf("invalid PDF date %q: offset out of range", dtz)
+		}
+		offset := hh*3600 + mm*60
+		if m[9] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	if t, err = asn1Date(year, month, day, hour, minute, second, loc); err != nil {
+		return t, fmt.Errorf("invalid PDF date %q: %s", dtz, err.Error())
+	}
+
+	return t, nil
+}
+
+// FormatPDFDate returns t as a PDF date such as "D:20210308160634-07'00'" (or
+// with Z when t is in UTC)
+func FormatPDFDate(t time.Time) string {
+	s := t.Format("D:20060102150405")
+	_, offset := t.Zone()
+	if offset == 0 && t.Location() == time.UTC {
+		return s + "Z"
+	}
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%s%02d'%02d'", s, sign, offset/3600, offset%3600/60)
+}
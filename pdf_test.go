@@ -0,0 +1,49 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePDFDate(t *testing.T) {
+	tests := map[string]string{
+		"D:20210308160634-07'00'": "2021-03-08T16:06:34-07:00",
+		"D:20210308160634-07'00":  "2021-03-08T16:06:34-07:00", // PDF 2.0 drops the last apostrophe
+		"D:20210308160634+0530":   "2021-03-08T16:06:34+05:30",
+		"D:20210308160634-07":     "2021-03-08T16:06:34-07:00",
+		"D:20210308160634Z00'00'": "2021-03-08T16:06:34Z",
+		"D:20210308160634Z":       "2021-03-08T16:06:34Z",
+		"D:20210308160634":        "2021-03-08T16:06:34Z", // DefaultLocation is nil
+		"D:202103":                "2021-03-01T00:00:00Z",
+		"D:2021":                  "2021-01-01T00:00:00Z",
+		"20210308160634-07'00'":   "2021-03-08T16:06:34-07:00",
+	}
+	for dtz, want := range tests {
+		got, err := ParsePDFDate(dtz)
+		if err != nil {
+			t.Errorf("ParsePDFDate(%q) error: %s", dtz, err)
+		} else if s := got.Format(time.RFC3339); s != want {
+			t.Errorf("ParsePDFDate(%q) = %s, want %s", dtz, s, want)
+		}
+	}
+
+	for _, dtz := range []string{"D:20211308", "D:20210230", "D:20210308160634-24'00'", "D:202", "D:20210308160634 -07'00'"} {
+		if _, err := ParsePDFDate(dtz); err == nil {
+			t.Errorf("ParsePDFDate(%q) should fail", dtz)
+		}
+	}
+
+	if got := GetPDFDateFormat("20210308160634"); got != "" {
+		t.Errorf("GetPDFDateFormat without D: = %q, want none", got)
+	}
+}
+
+func TestFormatPDFDate(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 0, time.FixedZone("", -7*3600))
+	if got := FormatPDFDate(ts); got != "D:20210308160634-07'00'" {
+		t.Errorf("FormatPDFDate = %s", got)
+	}
+	if got := FormatPDFDate(ts.UTC()); got != "D:20210308230634Z" {
+		t.Errorf("FormatPDFDate(UTC) = %s", got)
+	}
+}
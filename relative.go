@@ -0,0 +1,166 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RelativeFormat is to denote the format is a relative expression such as "2 hours ago" or "tomorrow 09:30"
+const RelativeFormat = "Relative"
+
+var (
+	reRelativeTerm  = regexp.MustCompile(`^([+-]?)((\d+)([a-z]+))+$`)
+	reRelativePair  = regexp.MustCompile(`(\d+)([a-z]+)`)
+	reRelativeClock = regexp.MustCompile(`^(\d{1,2}):(\d\d)(:(\d\d))?$`)
+)
+
+// relativeUnit returns the duration or calendar step for a unit name. The
+// calendar units (days and longer) are applied with AddDate so they keep the
+// wall clock across daylight saving changes. Note m is minutes and mo months.
+func relativeUnit(unit string) (d time.Duration, days, months int, ok bool) {
+	switch unit {
+	case "s", "sec", "secs", "second", "seconds":
+		return time.Second, 0, 0, true
+	case "m", "min", "mins", "minute", "minutes":
+		return time.Minute, 0, 0, true
+	case "h", "hr", "hrs", "hour", "hours":
+		return time.Hour, 0, 0, true
+	case "d", "day", "days":
+		return 0, 1, 0, true
+	case "w", "wk", "wks", "week", "weeks":
+		return 0, 7, 0, true
+	case "mo", "mos", "month", "months":
+		return 0, 0, 1, true
+	case "y", "yr", "yrs", "year", "years":
+		return 0, 0, 12, true
+	}
+	return 0, 0, 0, false
+}
+
+// GetRelativeFormat determines if the provided string is a relative expression
+func GetRelativeFormat(dtz string) (format string) {
+	if _, err := parseRelative(dtz, time.Unix(0, 0)); err == nil {
+		format = RelativeFormat
+		DebugPrintf("chronus.GetRelativeFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// ParseRelative parses a relative expression against ReferenceTime (zero is now)
+// in DefaultLocation (nil is UTC). Expressions are now, today, yesterday,
+// or tomorrow (optionally followed by a time of day such as 09:30), or amounts
+// such as "3 days ago", "in 2 weeks", "+1h30m", and "-2 weeks 3 days" (each
+// sign applies to its own term).
+func ParseRelative(dtz string) (time.Time, error) {
+	reference := ReferenceTime
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	return parseRelative(dtz, reference.In(defaultLocation()))
+}
+
+func parseRelative(dtz string, reference time.Time) (t time.Time, err error) {
+	fields := strings.Fields(strings.ToLower(dtz))
+	invalid := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("invalid relative expression %q", dtz)
+	}
+	if len(fields) == 0 {
+		return invalid()
+	}
+
+	// now, today, yesterday, tomorrow [hh:mm[:ss]]
+	day := 0
+	switch fields[0] {
+	case "now":
+		if len(fields) > 1 {
+			return invalid()
+		}
+		return reference, nil
+	case "yesterday":
+		day = -1
+	case "today":
+	case "tomorrow":
+		day = 1
+	default:
+		return parseRelativeAmount(dtz, fields, reference)
+	}
+	y, m, d := reference.Date()
+	t = time.Date(y, m, d+day, 0, 0, 0, 0, reference.Location())
+	switch len(fields) {
+	case 1:
+		return t, nil
+	case 2:
+		clock := reRelativeClock.FindStringSubmatch(fields[1])
+		if clock == nil {
+			return invalid()
+		}
+		hour, _ := strconv.Atoi(clock[1])
+		minute, _ := strconv.Atoi(clock[2])
+		second, _ := strconv.Atoi(clock[4])
+		if hour > 23 || minute > 59 || second > 59 {
+			return invalid()
+		}
+		return time.Date(y, m, d+day, hour, minute, second, 0, reference.Location()), nil
+	}
+	return invalid()
+}
+
+// parseRelativeAmount parses [in] amount unit ... [ago] and compact +1h30m terms
+func parseRelativeAmount(dtz string, fields []string, reference time.Time) (t time.Time, err error) {
+	invalid := fmt.Errorf("invalid relative expression %q", dtz)
+	direction := 1
+	if fields[0] == "in" {
+		fields = fields[1:]
+	} else if fields[len(fields)-1] == "ago" {
+		direction = -1
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return t, invalid
+	}
+
+	var (
+		duration     time.Duration
+		days, months int
+	)
+	add := func(sign int, amount, unit string) bool {
+		n, err := strconv.Atoi(amount)
+		d, ds, ms, ok := relativeUnit(unit)
+		if err != nil || !ok {
+			return false
+		}
+		n *= sign * direction
+		duration += time.Duration(n) * d
+		days += n * ds
+		months += n * ms
+		return true
+	}
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		sign := 1
+		if strings.HasPrefix(field, "-") {
+			sign = -1
+		}
+		if m := reRelativeTerm.FindStringSubmatch(field); m != nil {
+			// compact terms such as 3d, +1h30m, or -2w
+			for _, pair := range reRelativePair.FindAllStringSubmatch(field, -1) {
+				if !add(sign, pair[1], pair[2]) {
+					return t, invalid
+				}
+			}
+			continue
+		}
+		// a number followed by a unit such as "3 days" or "-2 hours"
+		if i+1 >= len(fields) || !add(sign, strings.TrimLeft(field, "+-"), fields[i+1]) {
+			return t, invalid
+		}
+		i++
+	}
+
+	return reference.AddDate(0, months, days).Add(duration), nil
+}
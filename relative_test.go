@@ -0,0 +1,47 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelative(t *testing.T) {
+	defer func(loc *time.Location, ref time.Time) { DefaultLocation, ReferenceTime = loc, ref }(DefaultLocation, ReferenceTime)
+	DefaultLocation, _ = time.LoadLocation("America/Denver")
+	// the evening before the 2021 spring forward in Denver
+	ReferenceTime = time.Date(2021, 3, 14, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"now", "2021-03-13T18:30:00-07:00"},
+		{"today", "2021-03-13T00:00:00-07:00"},
+		{"yesterday 09:30", "2021-03-12T09:30:00-07:00"},
+		{"tomorrow 16:06:34", "2021-03-14T16:06:34-06:00"},
+		{"2 hours ago", "2021-03-13T16:30:00-07:00"},
+		{"in 90 minutes", "2021-03-13T20:00:00-07:00"},
+		{"+1h30m", "2021-03-13T20:00:00-07:00"},
+		{"1 day", "2021-03-14T18:30:00-06:00"}, // calendar days keep the wall clock
+		{"24h", "2021-03-14T19:30:00-06:00"},
+		{"-2 weeks 3 days", "2021-03-02T18:30:00-07:00"},
+		{"1 mo ago", "2021-02-13T18:30:00-07:00"},
+		{"in 1y", "2022-03-13T18:30:00-06:00"}, // after the 2022 spring forward
+	}
+	for _, tt := range tests {
+		got, err := ParseRelative(tt.expr)
+		if err != nil {
+			t.Errorf("ParseRelative(%q) error: %s", tt.expr, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339); s != tt.want {
+			t.Errorf("ParseRelative(%q) = %s, want %s", tt.expr, s, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"now please", "today 25:00", "3 fortnights ago", "in", "ago", "2021-03-08"} {
+		if _, err := ParseRelative(expr); err == nil {
+			t.Errorf("ParseRelative(%q) should fail", expr)
+		}
+	}
+}
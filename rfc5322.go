@@ -0,0 +1,235 @@
+package chronus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RFC5322DateTime is to denote the format was read with the RFC 5322 section 3.3 date-time grammar
+const RFC5322DateTime = "RFC 5322 Date-Time"
+
+// rfc5322ObsZones are the obsolete alphabetic zones RFC 5322 section 4.3 defines offsets for
+var rfc5322ObsZones = map[string]int{
+	"UT":  0,
+	"GMT": 0,
+	"EST": -5 * 3600,
+	"EDT": -4 * 3600,
+	"CST": -6 * 3600,
+	"CDT": -5 * 3600,
+	"MST": -7 * 3600,
+	"MDT": -6 * 3600,
+	"PST": -8 * 3600,
+	"PDT": -7 * 3600,
+}
+
+// rfc5322Tokens removes comments (which may nest and contain quoted-pairs),
+// unfolds whitespace, and splits the rest into runs of letters, runs of digits,
+// and single punctuation characters
+func rfc5322Tokens(s string) (tokens []string, err error) {
+	var (
+		b     strings.Builder
+		depth int
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && depth > 0:
+			i++ // quoted-pair
+		case c == '(':
+			depth++
+			b.WriteByte(' ')
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("unbalanced ')' in %q", s)
+			}
+			depth--
+		case depth > 0:
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("unterminated comment in %q", s)
+	}
+
+	kind := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r):
+			return 1
+		case unicode.IsDigit(r):
+			return 2
+		case unicode.IsSpace(r):
+			return 0
+		}
+		return 3
+	}
+	start, prev := -1, 0
+	text := b.String()
+	for i, r := range text + " " {
+		k := kind(r)
+		if start >= 0 && (k != prev || k == 3) {
+			tokens = append(tokens, text[start:i])
+			start = -1
+		}
+		if start < 0 && k != 0 {
+			start = i
+		}
+		prev = k
+	}
+
+	return tokens, nil
+}
+
+// ParseRFC5322 parses a date-time such as an email Date: header using the full
+// RFC 5322 section 3.3 grammar including the obsolete forms: comments, folding
+// whitespace, an optional day of week (which is not checked), two and three
+// digit years, and alphabetic zones. Military and unknown zones are treated as
+// -0000 (UTC) as RFC 5322 recommends.
+func ParseRFC5322(dtz string) (t time.Time, err error) {
+	tokens, err := rfc5322Tokens(dtz)
+	if err != nil {
+		return t, err
+	}
+	invalid := func(reason string) (time.Time, error) {
+		return time.Time{}, fmt.Errorf("invalid RFC 5322 date-time %q: %s", dtz, reason)
+	}
+	i := 0
+	next := func() string {
+		if i < len(tokens) {
+			i++
+			return tokens[i-1]
+		}
+		return ""
+	}
+	number := func(min, max int) (int, bool) {
+		tok := next()
+		if len(tok) < min || len(tok) > max || strings.Trim(tok, "0123456789") != "" {
+			return 0, false
+		}
+		n, _ := strconv.Atoi(tok)
+		return n, true
+	}
+
+	// [ day-of-week "," ]
+	if i < len(tokens) && len(tokens[i]) > 0 && unicode.IsLetter(rune(tokens[i][0])) {
+		if nameIndex(next(), englishWeekdaysAbbr[:], englishWeekdays[:]) < 0 {
+			return invalid("unknown day of week")
+		}
+		if i < len(tokens) && tokens[i] == "," {
+			i++
+		}
+	}
+
+	day, ok := number(1, 2)
+	if !ok {
+		return invalid("expected a day of month")
+	}
+	m := nameIndex(next(), englishMonthsAbbr[:], englishMonths[:])
+	if m < 0 {
+		return invalid("expected a month name")
+	}
+	yearToken := ""
+	if i < len(tokens) {
+		yearToken = tokens[i]
+	}
+	year, ok := number(2, 9)
+	if !ok {
+		return invalid("expected a year")
+	}
+	switch len(yearToken) {
+	case 2:
+		// obs-year: 00-49 are 2000-2049 and 50-99 are 1950-1999
+		if year < 50 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	case 3:
+		year += 1900
+	}
+
+	hour, ok := number(1, 2)
+	if !ok || next() != ":" {
+		return invalid("expected a time of day")
+	}
+	minute, ok := number(1, 2)
+	if !ok {
+		return invalid("expected minutes")
+	}
+	second := 0
+	if i < len(tokens) && tokens[i] == ":" {
+		i++
+		if second, ok = number(1, 2); !ok {
+			return invalid("expected seconds")
+		}
+	}
+	if hour > 23 || minute > 59 || second > 60 {
+		return invalid("time of day out of range")
+	}
+
+	loc := time.UTC
+	switch zone := next(); {
+	case zone == "+" || zone == "-":
+		hhmm := next()
+		if len(hhmm) != 4 || strings.Trim(hhmm, "0123456789") != "" {
+			return invalid("expected a four digit zone offset")
+		}
+		hh, _ := strconv.Atoi(hhmm[:2])
+		mm, _ := strconv.Atoi(hhmm[2:])
+		offset := hh*3600 + mm*60
+		if zone == "-" {
+			offset = -offset
+		}
+		if offset != 0 {
+			loc = time.FixedZone("", offset)
+		}
+	case len(zone) == 0:
+		return invalid("missing zone")
+	case len(zone) == 1 && unicode.IsLetter(rune(zone[0])):
+		// military zones were used inconsistently so their offset is unknown
+	case unicode.IsLetter(rune(zone[0])):
+		if offset, ok := rfc5322ObsZones[strings.ToUpper(zone)]; ok {
+			if offset != 0 {
+				loc = time.FixedZone(strings.ToUpper(zone), offset)
+			}
+		} else {
+			loc = strptimeZone(zone)
+		}
+	default:
+		return invalid("unknown zone")
+	}
+
+	if i < len(tokens) {
+		return invalid(fmt.Sprintf("unexpected %q", tokens[i]))
+	}
+
+	t = time.Date(year, time.Month(m+1), day, hour, minute, 0, 0, loc)
+	if t.Day() != day {
+		return invalid(fmt.Sprintf("day %d is not in %s", day, time.Month(m+1)))
+	}
+	// a leap second (:60) rolls over into the next minute
+	return t.Add(time.Duration(second) * time.Second), nil
+}
+
+// GetRFC5322Format determines if the provided string is an RFC 5322 date-time
+func GetRFC5322Format(dtz string) (format string) {
+	if _, err := ParseRFC5322(dtz); err == nil {
+		format = RFC5322DateTime
+	}
+	return format
+}
+
+// nameIndex returns the index of name in either list ignoring case, or -1
+func nameIndex(name string, lists ...[]string) int {
+	for _, list := range lists {
+		for i, s := range list {
+			if strings.EqualFold(name, s) {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,52 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRFC5322(t *testing.T) {
+	tests := []struct {
+		dtz  string
+		want string
+	}{
+		{"Mon, 08 Mar 2021 16:06:34 -0700", "2021-03-08T16:06:34-07:00"},
+		{"8 Mar 2021 16:06 +0000", "2021-03-08T16:06:00Z"},
+		{"Mon, 8 Mar 21 16:06:34 MST", "2021-03-08T16:06:34-07:00"},
+		{"Mon, 8 Mar 99 16:06:34 GMT", "1999-03-08T16:06:34Z"},
+		{"Mon, 8 Mar 121 16:06:34 EDT", "2021-03-08T16:06:34-04:00"},
+		{"Mon (Monday), 8 Mar (March)\r\n 2021 16:06:34 -0700 (MST (Mountain))", "2021-03-08T16:06:34-07:00"},
+		{"monday , 8 march 2021 16 : 06 : 34 -0700", "2021-03-08T16:06:34-07:00"},
+		{"Mon, 8 Mar 2021 16:06:34 Q", "2021-03-08T16:06:34Z"},
+		{"Sat, 31 Dec 2016 23:59:60 +0000", "2017-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		if got := GetRFC5322Format(tt.dtz); got != RFC5322DateTime {
+			t.Errorf("GetRFC5322Format(%q) = %q, want %q", tt.dtz, got, RFC5322DateTime)
+		}
+		got, err := ParseRFC5322(tt.dtz)
+		if err != nil {
+			t.Errorf("ParseRFC5322(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339); s != tt.want {
+			t.Errorf("ParseRFC5322(%q) = %s, want %s", tt.dtz, s, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"Mon, 08 Mar 2021 16:06:34",
+		"Mon, 08 Mar 2021 16:06:34 -07",
+		"Mon, 30 Feb 2021 16:06:34 +0000",
+		"Mon, 08 Mar 2021 24:06:34 +0000",
+		"Moon, 08 Mar 2021 16:06:34 +0000",
+		"Mon, 08 Mar 2021 16:06:34 +0000 (unterminated",
+		"Mon, 08 Mar 2021 16:06:34 +0000 extra",
+		"2021-03-08T16:06:34Z",
+	}
+	for _, dtz := range invalid {
+		if _, err := ParseRFC5322(dtz); err == nil {
+			t.Errorf("ParseRFC5322(%q) should fail", dtz)
+		}
+	}
+}
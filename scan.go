@@ -0,0 +1,93 @@
+package chronus
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// scanOpening is the punctuation skipped before a date-time in free text such as "(2021-03-08)"
+const scanOpening = "([{<\"'`"
+
+// TextMatch is a date-time found in free text
+type TextMatch struct {
+	Parsed
+	Start int    // byte offset of the first byte
+	End   int    // byte offset after the last byte, so text[Start:End] is Text
+	Text  string // the date-time as written
+}
+
+// FindTimes returns every date-time in text in order with its byte offsets and
+// detected format. Each word with a digit or a month or weekday name is tried
+// as the start of a date-time (after any opening brackets or quotes) with
+// ParseLeading, so the longest run of words on the same line that parses wins
+// and ordinary words and small numbers are not read as times.
+func FindTimes(text string) (matches []TextMatch) {
+	wordStart := true
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsSpace(r) {
+			wordStart = true
+			i += size
+			continue
+		}
+		if !wordStart {
+			i += size
+			continue
+		}
+		wordStart = false
+		if !isScanWord(text[i:]) {
+			// ordinary words and punctuation cannot start a date-time
+			i += size
+			continue
+		}
+
+		lineEnd := len(text)
+		if n := strings.IndexByte(text[i:], '\n'); n >= 0 {
+			lineEnd = i + n
+		}
+		next := i + size
+		for j := i; j < lineEnd; j++ {
+			p, start, end, err := ParseLeading(text[j:lineEnd])
+			if err == nil {
+				matches = append(matches, TextMatch{Parsed: p, Start: j + start, End: j + end, Text: text[j+start : j+end]})
+				next = j + end
+				break
+			}
+			if !strings.ContainsRune(scanOpening, rune(text[j])) {
+				break
+			}
+		}
+		i = next
+	}
+
+	return matches
+}
+
+// isScanWord reports whether the word at the start of text (after any opening
+// brackets or quotes) contains a digit or is a month or weekday name, which
+// every date-time FindTimes can find starts with
+func isScanWord(text string) bool {
+	end := strings.IndexFunc(text, unicode.IsSpace)
+	if end < 0 {
+		end = len(text)
+	}
+	word := strings.TrimLeft(text[:end], scanOpening)
+	return strings.IndexFunc(word, unicode.IsDigit) >= 0 || isDateName(word)
+}
+
+// ReplaceTimes returns text with each date-time FindTimes finds replaced by the
+// result of replace, such as the time in another format or zone or the original
+// text followed by an annotation
+func ReplaceTimes(text string, replace func(m TextMatch) string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range FindTimes(text) {
+		b.WriteString(text[last:m.Start])
+		b.WriteString(replace(m))
+		last = m.End
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
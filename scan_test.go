@@ -0,0 +1,71 @@
+package chronus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindTimes(t *testing.T) {
+	text := "At 2021-03-08T16:06:34-07:00 the API went down (first alert 2021-03-08T23:05:00Z).\n" +
+		"Paged on-call at Mon, 08 Mar 2021 16:10:00 -0700, ack'd by Alice.\n" +
+		"Recovered by 1615249800; followup on March 9, 2021 with 200 hosts in May.\n" +
+		"Met at 2021-03-08 16:06:34 -0700 MST about ticket 12345."
+	tests := []struct {
+		text   string
+		format string
+		want   string
+	}{
+		{"2021-03-08T16:06:34-07:00", "2006-01-02T15:04:05Z07:00", "2021-03-08T23:06:34Z"},
+		{"2021-03-08T23:05:00Z", "2006-01-02T15:04:05Z0700", "2021-03-08T23:05:00Z"},
+		{"Mon, 08 Mar 2021 16:10:00 -0700", RFC5322DateTime, "2021-03-08T23:10:00Z"},
+		{"1615249800", UnixTimeStamp, "2021-03-09T00:30:00Z"},
+		{"March 9, 2021", "Localized (en)", "2021-03-09T00:00:00Z"},
+		{"2021-03-08 16:06:34 -0700", SQLDateTimeYearToSecondWithOffset, "2021-03-08T23:06:34Z"},
+	}
+
+	matches := FindTimes(text)
+	if len(matches) != len(tests) {
+		t.Fatalf("FindTimes found %d date-times, want %d: %+v", len(matches), len(tests), matches)
+	}
+	for i, tt := range tests {
+		m := matches[i]
+		if m.Text != tt.text || text[m.Start:m.End] != tt.text {
+			t.Errorf("match %d = %q (%d-%d), want %q", i, m.Text, m.Start, m.End, tt.text)
+		}
+		if m.Format != tt.format {
+			t.Errorf("match %d %q format = %q, want %q", i, m.Text, m.Format, tt.format)
+		}
+		if got := m.Time.UTC().Format(time.RFC3339); got != tt.want {
+			t.Errorf("match %d %q = %s, want %s", i, m.Text, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceTimes(t *testing.T) {
+	annotate := func(m TextMatch) string {
+		return m.Text + " [" + m.Time.UTC().Format(time.RFC3339) + "]"
+	}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"no dates in 200 words", "no dates in 200 words"},
+		{"Met at 2021-03-08 16:06:34 -0700 MST", "Met at 2021-03-08 16:06:34 -0700 [2021-03-08T23:06:34Z] MST"},
+		{"(2021-03-08T16:06:34Z)", "(2021-03-08T16:06:34Z [2021-03-08T16:06:34Z])"},
+	}
+	for _, tt := range tests {
+		if got := ReplaceTimes(tt.text, annotate); got != tt.want {
+			t.Errorf("ReplaceTimes(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkFindTimes(b *testing.B) {
+	text := strings.Repeat("The deploy rolled 200 hosts in 15 minutes on Monday, see ticket 12345 from May.\n"+
+		"First alert at 2021-03-08T23:05:00Z and recovery by Mon, 08 Mar 2021 16:10:00 -0700.\n", 100)
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		FindTimes(text)
+	}
+}
@@ -0,0 +1,151 @@
+package chronus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpreadsheetSystem identifies a spreadsheet serial date system. Serial dates
+// count days (with the time of day as the fraction) from a base date and have
+// no time zone, so they are read and written as wall clock times.
+type SpreadsheetSystem int
+
+const (
+	// Excel1900 is the Excel (and Lotus 1-2-3) default where 1 is 1900-01-01 and
+	// 60 is the nonexistent 1900-02-29
+	Excel1900 SpreadsheetSystem = iota
+	// Excel1904 is the Excel for Mac default where 0 is 1904-01-01
+	Excel1904
+	// LibreOffice counts from 1899-12-30 without the 1900 leap year bug
+	LibreOffice
+	// GoogleSheets counts from 1899-12-30 without the 1900 leap year bug
+	GoogleSheets
+)
+
+const nanosecondsPerDay = 86400 * int64(time.Second)
+
+var (
+	// SpreadsheetSystems lists the supported serial date systems in display order
+	SpreadsheetSystems = []SpreadsheetSystem{Excel1900, Excel1904, LibreOffice, GoogleSheets}
+
+	spreadsheetNames = map[string]SpreadsheetSystem{
+		"excel":        Excel1900,
+		"excel1900":    Excel1900,
+		"lotus":        Excel1900,
+		"excel1904":    Excel1904,
+		"mac":          Excel1904,
+		"libreoffice":  LibreOffice,
+		"openoffice":   LibreOffice,
+		"google":       GoogleSheets,
+		"googlesheets": GoogleSheets,
+		"sheets":       GoogleSheets,
+	}
+
+	// lotusLeapDay is the first day after the nonexistent 1900-02-29 in the Excel 1900 system
+	lotusLeapDay = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func (s SpreadsheetSystem) String() string {
+	switch s {
+	case Excel1900:
+		return "Excel 1900"
+	case Excel1904:
+		return "Excel 1904"
+	case LibreOffice:
+		return "LibreOffice"
+	case GoogleSheets:
+		return "Google Sheets"
+	}
+	return fmt.Sprintf("SpreadsheetSystem(%d)", int(s))
+}
+
+// base returns the date that serial 0 refers to
+func (s SpreadsheetSystem) base() time.Time {
+	if s == Excel1904 {
+		return time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseSpreadsheetSystem converts a name such as excel, excel1904, libreoffice, or sheets into a SpreadsheetSystem
+func ParseSpreadsheetSystem(name string) (SpreadsheetSystem, error) {
+	key := strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name))
+	if system, ok := spreadsheetNames[key]; ok {
+		return system, nil
+	}
+	return Excel1900, fmt.Errorf("unknown spreadsheet system %q (expected excel, excel1904, libreoffice, or sheets)", name)
+}
+
+// SpreadsheetSerial converts the wall clock of t into a serial date
+func SpreadsheetSerial(t time.Time, system SpreadsheetSystem) float64 {
+	f, _ := strconv.ParseFloat(SpreadsheetSerialString(t, system, 10), 64)
+	return f
+}
+
+// SpreadsheetSerialString returns the exact serial date for the wall clock of t
+// with precision fractional digits (truncated)
+func SpreadsheetSerialString(t time.Time, system SpreadsheetSystem, precision int) string {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	days := (date.Unix() - system.base().Unix()) / 86400
+	if system == Excel1900 && date.Before(lotusLeapDay) {
+		// Lotus 1-2-3 treated 1900 as a leap year so earlier days are one off
+		days--
+	}
+	nanos := int64(t.Hour())*int64(time.Hour) + int64(t.Minute())*int64(time.Minute) +
+		int64(t.Second())*int64(time.Second) + int64(t.Nanosecond())
+
+	return dayDecimalString(days, nanos, precision)
+}
+
+// dayDecimalString returns days plus nanos (nanoseconds into the day) as an exact
+// decimal number of days with precision fractional digits (truncated)
+func dayDecimalString(days, nanos int64, precision int) string {
+	sign := ""
+	if days < 0 {
+		sign, days = "-", -days
+		if nanos > 0 {
+			// -1.25 is stored as day -2 plus three quarters of a day
+			days, nanos = days-1, nanosecondsPerDay-nanos
+		}
+	}
+
+	if precision <= 0 {
+		return fmt.Sprintf("%s%d", sign, days)
+	}
+	digits := make([]byte, precision)
+	for i := range digits {
+		nanos *= 10
+		digits[i] = byte('0' + nanos/nanosecondsPerDay)
+		nanos %= nanosecondsPerDay
+	}
+	return fmt.Sprintf("%s%d.%s", sign, days, digits)
+}
+
+// SpreadsheetSerialToTime converts a serial date into a wall clock time in DefaultLocation
+func SpreadsheetSerialToTime(serial float64, system SpreadsheetSystem) time.Time {
+	t, _ := ParseSpreadsheetSerial(strconv.FormatFloat(serial, 'f', 10, 64), system)
+	return t
+}
+
+// ParseSpreadsheetSerial exactly converts a decimal serial date such as "44263.6708"
+// into a wall clock time in DefaultLocation (nil is UTC) like other inputs without
+// a zone. In the Excel 1900 system serial 60, the nonexistent
+// 1900-02-29, is returned as 1900-02-28.
+func ParseSpreadsheetSerial(s string, system SpreadsheetSystem) (t time.Time, err error) {
+	// days are handled like seconds so the fraction is kept to nine digits exactly
+	d, err := ParseUnixDecimal(s)
+	if err != nil {
+		return t, fmt.Errorf("invalid spreadsheet serial date %q", strings.TrimSpace(s))
+	}
+	days, frac := d.Unix(), int64(d.Nanosecond())
+	if system == Excel1900 && days < 60 {
+		days++
+	}
+
+	date := system.base().AddDate(0, 0, int(days))
+	nanos := frac * 86400
+	sec := nanos / int64(time.Second)
+	return time.Date(date.Year(), date.Month(), date.Day(), int(sec/3600), int(sec/60%60), int(sec%60), int(nanos%int64(time.Second)), defaultLocation()), nil
+}
@@ -0,0 +1,79 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpreadsheetSerial(t *testing.T) {
+	tests := []struct {
+		serial string
+		system SpreadsheetSystem
+		want   string
+	}{
+		{"0", Excel1900, "1899-12-31T00:00:00Z"}, // shown by Excel as 1900-01-00
+		{"1", Excel1900, "1900-01-01T00:00:00Z"},
+		{"59", Excel1900, "1900-02-28T00:00:00Z"},
+		{"60", Excel1900, "1900-02-28T00:00:00Z"}, // the nonexistent 1900-02-29
+		{"61", Excel1900, "1900-03-01T00:00:00Z"},
+		{"44263.6708333333", Excel1900, "2021-03-08T16:05:59.9999712Z"}, // nine fraction digits are kept
+		{"44263.75", Excel1900, "2021-03-08T18:00:00Z"},
+		{"0", Excel1904, "1904-01-01T00:00:00Z"},
+		{"42801.75", Excel1904, "2021-03-08T18:00:00Z"},
+		{"0", GoogleSheets, "1899-12-30T00:00:00Z"},
+		{"60", GoogleSheets, "1900-02-28T00:00:00Z"},
+		{"61", GoogleSheets, "1900-03-01T00:00:00Z"},
+		{"-1.25", LibreOffice, "1899-12-28T18:00:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := ParseSpreadsheetSerial(tt.serial, tt.system)
+		if err != nil {
+			t.Errorf("ParseSpreadsheetSerial(%s, %s) error: %s", tt.serial, tt.system, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseSpreadsheetSerial(%s, %s) = %s, want %s", tt.serial, tt.system, s, tt.want)
+		}
+	}
+
+	if _, err := ParseSpreadsheetSerial("44263,75", Excel1900); err == nil {
+		t.Errorf("ParseSpreadsheetSerial(44263,75) should fail")
+	}
+}
+
+func TestSpreadsheetSerialString(t *testing.T) {
+	tests := []struct {
+		date   time.Time
+		system SpreadsheetSystem
+		want   string
+	}{
+		{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), Excel1900, "1.00"},
+		{time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC), Excel1900, "59.00"},
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), Excel1900, "61.00"},
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), GoogleSheets, "61.00"},
+		{time.Date(2021, 3, 8, 18, 0, 0, 0, time.UTC), Excel1900, "44263.75"},
+		{time.Date(2021, 3, 8, 18, 0, 0, 0, time.FixedZone("", -7*3600)), Excel1900, "44263.75"},
+		{time.Date(2021, 3, 8, 18, 0, 0, 0, time.UTC), Excel1904, "42801.75"},
+		{time.Date(1899, 12, 28, 18, 0, 0, 0, time.UTC), LibreOffice, "-1.25"},
+	}
+	for _, tt := range tests {
+		if got := SpreadsheetSerialString(tt.date, tt.system, 2); got != tt.want {
+			t.Errorf("SpreadsheetSerialString(%s, %s) = %s, want %s", tt.date, tt.system, got, tt.want)
+		}
+	}
+}
+
+func TestSpreadsheet1904RoundTrip(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2021, 3, 8, 16, 6, 34, 500000000, time.UTC),
+	} {
+		serial := SpreadsheetSerialString(want, Excel1904, 12)
+		got, err := ParseSpreadsheetSerial(serial, Excel1904)
+		// nine digits of a day are 86.4 microseconds
+		if diff := got.Sub(want); err != nil || diff < -100*time.Microsecond || diff > 100*time.Microsecond {
+			t.Errorf("Excel 1904 %s -> %s -> %s, %v", want, serial, got, err)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package chronus
+
+// sysStatx is the statx(2) system call number on linux/arm64
+const sysStatx = 291
@@ -0,0 +1,8 @@
+//go:build linux && (mips64 || mips64le)
+// +build linux
+// +build mips64 mips64le
+
+package chronus
+
+// sysStatx is the statx(2) system call number on linux/mips64 and linux/mips64le (n64)
+const sysStatx = 5326
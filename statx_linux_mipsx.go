@@ -0,0 +1,8 @@
+//go:build linux && (mips || mipsle)
+// +build linux
+// +build mips mipsle
+
+package chronus
+
+// sysStatx is the statx(2) system call number on linux/mips and linux/mipsle (o32)
+const sysStatx = 4366
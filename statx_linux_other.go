@@ -0,0 +1,7 @@
+//go:build linux && !amd64 && !386 && !arm && !arm64 && !riscv64 && !s390x && !ppc64 && !ppc64le && !mips && !mipsle && !mips64 && !mips64le
+// +build linux,!amd64,!386,!arm,!arm64,!riscv64,!s390x,!ppc64,!ppc64le,!mips,!mipsle,!mips64,!mips64le
+
+package chronus
+
+// sysStatx is zero where the statx(2) number is not known so stat(2) is used
+const sysStatx = 0
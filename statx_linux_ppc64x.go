@@ -0,0 +1,8 @@
+//go:build linux && (ppc64 || ppc64le)
+// +build linux
+// +build ppc64 ppc64le
+
+package chronus
+
+// sysStatx is the statx(2) system call number on linux/ppc64 and linux/ppc64le
+const sysStatx = 383
@@ -0,0 +1,592 @@
+package chronus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/runeimp/chronus/tzinfo"
+)
+
+// strftimeComposites are the conversions that are shorthand for other conversions
+var strftimeComposites = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'D': "%m/%d/%y",
+	'F': "%Y-%m-%d",
+	'r': "%I:%M:%S %p",
+	'R': "%H:%M",
+	'T': "%H:%M:%S",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+	'+': "%a %b %e %H:%M:%S %Z %Y",
+}
+
+// strftimeDirective is a single parsed conversion specification such as %-d or %3N
+type strftimeDirective struct {
+	flag   byte // one of '-', '_', '0', '^', '#' or zero
+	width  int
+	colons int // number of colons for %:z and %::z
+	verb   byte
+}
+
+// IsStrftime reports whether the format looks like a strftime format rather than a Go layout
+func IsStrftime(format string) bool {
+	return strings.Contains(format, "%")
+}
+
+// Strftime formats t according to a POSIX strftime format string. The common GNU
+// extensions are supported as well: the padding flags -, _, 0 and ^, field widths
+// (e.g. %3N for milliseconds), %N for nanoseconds, %s for the UNIX timestamp,
+// %:z and %::z for offsets with colons, and Python's %f for microseconds.
+func Strftime(t time.Time, format string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		d, n := parseStrftimeDirective(format[i+1:])
+		i += n
+		if d.verb == 0 {
+			b.WriteString(format[i-n : i+1])
+			continue
+		}
+
+		b.WriteString(strftimeValue(t, d))
+	}
+
+	return b.String()
+}
+
+// parseStrftimeDirective reads the flag, width, colons, and verb following a %
+func parseStrftimeDirective(s string) (d strftimeDirective, n int) {
+	if n < len(s) && strings.IndexByte("-_0^#", s[n]) >= 0 {
+		d.flag = s[n]
+		n++
+	}
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		d.width = d.width*10 + int(s[n]-'0')
+		n++
+	}
+	for n < len(s) && s[n] == ':' {
+		d.colons++
+		n++
+	}
+	if n < len(s) {
+		d.verb = s[n]
+		n++
+	}
+	return d, n
+}
+
+func strftimeValue(t time.Time, d strftimeDirective) string {
+	if composite, ok := strftimeComposites[d.verb]; ok {
+		return strftimeText(Strftime(t, composite), d)
+	}
+
+	isoYear, isoWeek := t.ISOWeek()
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	switch d.verb {
+	case 'a':
+		return strftimeText(t.Weekday().String()[:3], d)
+	case 'A':
+		return strftimeText(t.Weekday().String(), d)
+	case 'b', 'h':
+		return strftimeText(t.Month().String()[:3], d)
+	case 'B':
+		return strftimeText(t.Month().String(), d)
+	case 'C':
+		return strftimeNumber(t.Year()/100, 2, '0', d)
+	case 'd':
+		return strftimeNumber(t.Day(), 2, '0', d)
+	case 'e':
+		return strftimeNumber(t.Day(), 2, ' ', d)
+	case 'f':
+		return strftimeFraction(t.Nanosecond(), 6, d)
+	case 'g':
+		return strftimeNumber(isoYear%100, 2, '0', d)
+	case 'G':
+		return strftimeNumber(isoYear, 4, '0', d)
+	case 'H':
+		return strftimeNumber(t.Hour(), 2, '0', d)
+	case 'I':
+		return strftimeNumber(hour12, 2, '0', d)
+	case 'j':
+		return strftimeNumber(t.YearDay(), 3, '0', d)
+	case 'k':
+		return strftimeNumber(t.Hour(), 2, ' ', d)
+	case 'l':
+		return strftimeNumber(hour12, 2, ' ', d)
+	case 'm':
+		return strftimeNumber(int(t.Month()), 2, '0', d)
+	case 'M':
+		return strftimeNumber(t.Minute(), 2, '0', d)
+	case 'n':
+		return "\n"
+	case 'N':
+		return strftimeFraction(t.Nanosecond(), 9, d)
+	case 'p':
+		if d.flag == '#' {
+			return strings.ToLower(t.Format("PM"))
+		}
+		return strftimeText(t.Format("PM"), d)
+	case 'P':
+		return t.Format("pm")
+	case 's':
+		return strftimeText(strconv.FormatInt(t.Unix(), 10), d)
+	case 'S':
+		return strftimeNumber(t.Second(), 2, '0', d)
+	case 't':
+		return "\t"
+	case 'u':
+		weekday := int(t.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return strftimeNumber(weekday, 1, '0', d)
+	case 'U':
+		return strftimeNumber((t.YearDay()+6-int(t.Weekday()))/7, 2, '0', d)
+	case 'V':
+		return strftimeNumber(isoWeek, 2, '0', d)
+	case 'w':
+		return strftimeNumber(int(t.Weekday()), 1, '0', d)
+	case 'W':
+		return strftimeNumber((t.YearDay()+6-(int(t.Weekday())+6)%7)/7, 2, '0', d)
+	case 'y':
+		return strftimeNumber(t.Year()%100, 2, '0', d)
+	case 'Y':
+		return strftimeNumber(t.Year(), 4, '0', d)
+	case 'z':
+		_, offset := t.Zone()
+		return strftimeOffset(offset, d.colons)
+	case 'Z':
+		zone, _ := t.Zone()
+		return strftimeText(zone, d)
+	case '%':
+		return "%"
+	}
+
+	// Unknown conversions are passed through untouched like GNU date does
+	return "%" + string(d.verb)
+}
+
+// strftimeNumber pads a number to width using the default pad unless a flag overrides it
+func strftimeNumber(n, width int, pad byte, d strftimeDirective) string {
+	if d.width > 0 {
+		width = d.width
+	}
+	switch d.flag {
+	case '-':
+		return strconv.Itoa(n)
+	case '_':
+		pad = ' '
+	case '0':
+		pad = '0'
+	}
+
+	s := strconv.Itoa(n)
+	negative := n < 0
+	if negative {
+		s = s[1:]
+	}
+	if len(s) < width {
+		s = strings.Repeat(string(pad), width-len(s)) + s
+	}
+	if negative {
+		s = "-" + s
+	}
+	return s
+}
+
+// strftimeFraction returns the fractional second truncated to width digits
+func strftimeFraction(nanos, digits int, d strftimeDirective) string {
+	s := fmt.Sprintf("%09d", nanos)
+	if d.width > 0 && d.width < 9 {
+		digits = d.width
+	}
+	return s[:digits]
+}
+
+func strftimeOffset(offset, colons int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, offset/60%60, offset%60
+
+	switch colons {
+	case 1:
+		return fmt.Sprintf("%c%02d:%02d", sign, hours, minutes)
+	case 2:
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, hours, minutes)
+}
+
+func strftimeText(s string, d strftimeDirective) string {
+	if d.flag == '^' {
+		s = strings.ToUpper(s)
+	}
+	if d.width > len(s) {
+		s = strings.Repeat(" ", d.width-len(s)) + s
+	}
+	return s
+}
+
+// strptimeFields collects the values found while parsing
+type strptimeFields struct {
+	year, month, day       int
+	hour, minute, second   int
+	nanosecond             int
+	century                int
+	yearDay                int
+	weekday                int // 0 = Sunday
+	isoYear, isoWeek       int
+	weekSunday, weekMonday int
+	pm, hasPM              bool
+	hasYear, hasCentury    bool
+	hasYearDay, hasWeekday bool
+	hasISOYear, hasISOWeek bool
+	hasWeekSun, hasWeekMon bool
+	unix                   int64
+	hasUnix                bool
+	location               *time.Location
+}
+
+// Strptime parses value according to a strftime format string. It accepts the same
+// conversions as Strftime. Whitespace in the format matches zero or more whitespace
+// characters in the value. Missing date fields default like time.Parse does, and
+// the result is in DefaultLocation (nil is UTC) unless the value includes an
+// offset or zone.
+func Strptime(value, format string) (t time.Time, err error) {
+	f := strptimeFields{month: 1, day: 1}
+	rest, err := strptime(value, format, &f)
+	if err != nil {
+		return t, err
+	}
+	if len(strings.TrimSpace(rest)) > 0 {
+		return t, fmt.Errorf("strptime: extra text %q after %q", rest, format)
+	}
+
+	return f.time()
+}
+
+func strptime(value, format string, f *strptimeFields) (rest string, err error) {
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+
+		if unicode.IsSpace(rune(c)) {
+			value = strings.TrimLeftFunc(value, unicode.IsSpace)
+			continue
+		}
+
+		if c != '%' || i+1 == len(format) {
+			if len(value) == 0 || value[0] != c {
+				return value, fmt.Errorf("strptime: expected %q in %q", string(c), value)
+			}
+			value = value[1:]
+			continue
+		}
+
+		d, n := parseStrftimeDirective(format[i+1:])
+		i += n
+
+		if composite, ok := strftimeComposites[d.verb]; ok {
+			value, err = strptime(value, composite, f)
+			if err != nil {
+				return value, err
+			}
+			continue
+		}
+
+		value, err = strptimeDirective(value, d, f)
+		if err != nil {
+			return value, err
+		}
+	}
+
+	return value, nil
+}
+
+func strptimeDirective(value string, d strftimeDirective, f *strptimeFields) (rest string, err error) {
+	var n int
+
+	switch d.verb {
+	case 'a', 'A':
+		f.weekday, value, err = strptimeName(value, englishWeekdays[:], englishWeekdaysAbbr[:])
+		f.hasWeekday = true
+		return value, err
+	case 'b', 'B', 'h':
+		n, value, err = strptimeName(value, englishMonths[:], englishMonthsAbbr[:])
+		f.month = n + 1
+		return value, err
+	case 'C':
+		f.century, value, err = strptimeInt(value, 2, false)
+		f.hasCentury = true
+	case 'd', 'e':
+		f.day, value, err = strptimeInt(value, 2, false)
+	case 'f', 'N':
+		digits := 0
+		for digits < len(value) && digits < 9 && value[digits] >= '0' && value[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 {
+			return value, fmt.Errorf("strptime: expected fractional seconds in %q", value)
+		}
+		f.nanosecond, _ = strconv.Atoi(value[:digits] + strings.Repeat("0", 9-digits))
+		value = value[digits:]
+	case 'g':
+		f.isoYear, value, err = strptimeInt(value, 2, false)
+		f.isoYear += 2000
+		f.hasISOYear = true
+	case 'G':
+		f.isoYear, value, err = strptimeInt(value, 4, true)
+		f.hasISOYear = true
+	case 'H', 'k':
+		f.hour, value, err = strptimeInt(value, 2, false)
+	case 'I', 'l':
+		f.hour, value, err = strptimeInt(value, 2, false)
+		f.hour %= 12
+	case 'j':
+		f.yearDay, value, err = strptimeInt(value, 3, false)
+		f.hasYearDay = true
+	case 'm':
+		f.month, value, err = strptimeInt(value, 2, false)
+	case 'M':
+		f.minute, value, err = strptimeInt(value, 2, false)
+	case 'n', 't':
+		value = strings.TrimLeftFunc(value, unicode.IsSpace)
+	case 'p', 'P':
+		upper := strings.ToUpper(value)
+		switch {
+		case strings.HasPrefix(upper, "AM"):
+			f.pm = false
+		case strings.HasPrefix(upper, "PM"):
+			f.pm = true
+		default:
+			return value, fmt.Errorf("strptime: expected AM or PM in %q", value)
+		}
+		f.hasPM = true
+		value = value[2:]
+	case 's':
+		var s int
+		s, value, err = strptimeInt(value, 19, true)
+		f.unix = int64(s)
+		f.hasUnix = true
+	case 'S':
+		f.second, value, err = strptimeInt(value, 2, false)
+	case 'u':
+		f.weekday, value, err = strptimeInt(value, 1, false)
+		f.weekday %= 7
+		f.hasWeekday = true
+	case 'U':
+		f.weekSunday, value, err = strptimeInt(value, 2, false)
+		f.hasWeekSun = true
+	case 'V':
+		f.isoWeek, value, err = strptimeInt(value, 2, false)
+		f.hasISOWeek = true
+	case 'w':
+		f.weekday, value, err = strptimeInt(value, 1, false)
+		f.hasWeekday = true
+	case 'W':
+		f.weekMonday, value, err = strptimeInt(value, 2, false)
+		f.hasWeekMon = true
+	case 'y':
+		f.year, value, err = strptimeInt(value, 2, false)
+		if !f.hasCentury {
+			// POSIX: 69-99 are 1969-1999 and 00-68 are 2000-2068
+			if f.year < 69 {
+				f.year += 2000
+			} else {
+				f.year += 1900
+			}
+		}
+		f.hasYear = true
+	case 'Y':
+		f.year, value, err = strptimeInt(value, 4, true)
+		f.hasYear = true
+	case 'z':
+		return strptimeOffset(value, f)
+	case 'Z':
+		i := 0
+		for i < len(value) && unicode.IsLetter(rune(value[i])) {
+			i++
+		}
+		if i == 0 {
+			return value, fmt.Errorf("strptime: expected time zone abbreviation in %q", value)
+		}
+		f.location = strptimeZone(value[:i])
+		value = value[i:]
+	case '%':
+		if len(value) == 0 || value[0] != '%' {
+			return value, fmt.Errorf("strptime: expected %% in %q", value)
+		}
+		value = value[1:]
+	default:
+		return value, fmt.Errorf("strptime: unsupported conversion %%%c", d.verb)
+	}
+
+	return value, err
+}
+
+// strptimeInt reads up to maxDigits digits, skipping leading spaces used as padding
+func strptimeInt(value string, maxDigits int, signed bool) (n int, rest string, err error) {
+	value = strings.TrimLeft(value, " ")
+	i := 0
+	if signed && len(value) > 0 && (value[0] == '-' || value[0] == '+') {
+		i++
+	}
+	start := i
+	for i < len(value) && i-start < maxDigits && value[i] >= '0' && value[i] <= '9' {
+		i++
+	}
+	if i == start {
+		return 0, value, fmt.Errorf("strptime: expected number in %q", value)
+	}
+
+	n, err = strconv.Atoi(value[:i])
+	return n, value[i:], err
+}
+
+// strptimeName matches a full or abbreviated English name and returns its index
+func strptimeName(value string, full, abbr []string) (index int, rest string, err error) {
+	lower := strings.ToLower(value)
+	for i := range full {
+		if strings.HasPrefix(lower, strings.ToLower(full[i])) {
+			return i, value[len(full[i]):], nil
+		}
+	}
+	for i := range abbr {
+		if strings.HasPrefix(lower, strings.ToLower(abbr[i])) {
+			return i, value[len(abbr[i]):], nil
+		}
+	}
+	return 0, value, fmt.Errorf("strptime: unknown name in %q", value)
+}
+
+// strptimeOffset reads Z, ±hh, ±hhmm, ±hh:mm, or ±hh:mm:ss
+func strptimeOffset(value string, f *strptimeFields) (rest string, err error) {
+	if len(value) > 0 && (value[0] == 'Z' || value[0] == 'z') {
+		f.location = time.UTC
+		return value[1:], nil
+	}
+	if len(value) < 3 || (value[0] != '+' && value[0] != '-') {
+		return value, fmt.Errorf("strptime: expected offset in %q", value)
+	}
+
+	i := 1
+	digits := ""
+	for i < len(value) && len(digits) < 6 {
+		if value[i] >= '0' && value[i] <= '9' {
+			digits += value[i : i+1]
+		} else if value[i] != ':' {
+			break
+		}
+		i++
+	}
+	for len(digits) < 6 {
+		digits += "0"
+	}
+
+	hours, _ := strconv.Atoi(digits[0:2])
+	minutes, _ := strconv.Atoi(digits[2:4])
+	seconds, _ := strconv.Atoi(digits[4:6])
+	offset := hours*3600 + minutes*60 + seconds
+	if value[0] == '-' {
+		offset = -offset
+	}
+	f.location = time.FixedZone("", offset)
+
+	return value[i:], nil
+}
+
+// strptimeZone resolves a time zone abbreviation using the country code when known
+func strptimeZone(abbr string) *time.Location {
+	switch strings.ToUpper(abbr) {
+	case "UTC", "GMT", "UT", "Z":
+		return time.UTC
+	}
+
+	switch strings.ToUpper(CountryCode) {
+	case "US", "USA":
+		if tzloc, err := tzinfo.GetUSTimeZoneLocationByTZAbbreviation(abbr); err == nil {
+			_, offset := tzloc.Zone()
+			return time.FixedZone(abbr, offset)
+		}
+	}
+
+	if zone, offset := time.Now().Zone(); zone == abbr {
+		return time.FixedZone(abbr, offset)
+	}
+
+	return time.FixedZone(abbr, 0)
+}
+
+// time assembles the parsed fields into a time.Time
+func (f *strptimeFields) time() (time.Time, error) {
+	loc := f.location
+	if loc == nil {
+		loc = defaultLocation()
+	}
+
+	if f.hasUnix {
+		return time.Unix(f.unix, int64(f.nanosecond)).In(loc), nil
+	}
+
+	if f.hasCentury {
+		if f.hasYear {
+			f.year = f.century*100 + f.year%100
+		} else {
+			f.year = f.century * 100
+		}
+	}
+	if f.hasPM && f.pm {
+		f.hour += 12
+	}
+
+	var date time.Time
+	switch {
+	case f.hasISOYear && f.hasISOWeek:
+		// ISO weeks start on Monday and week 1 contains January 4th
+		weekday := 1
+		if f.hasWeekday {
+			weekday = (f.weekday+6)%7 + 1
+		}
+		jan4 := time.Date(f.isoYear, time.January, 4, 0, 0, 0, 0, loc)
+		week1Monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		date = week1Monday.AddDate(0, 0, (f.isoWeek-1)*7+weekday-1)
+	case f.hasYearDay:
+		date = time.Date(f.year, time.January, f.yearDay, 0, 0, 0, 0, loc)
+	case f.hasWeekSun && f.hasWeekday:
+		jan1 := time.Date(f.year, time.January, 1, 0, 0, 0, 0, loc)
+		firstSunday := (7 - int(jan1.Weekday())) % 7
+		date = jan1.AddDate(0, 0, firstSunday+(f.weekSunday-1)*7+f.weekday)
+	case f.hasWeekMon && f.hasWeekday:
+		jan1 := time.Date(f.year, time.January, 1, 0, 0, 0, 0, loc)
+		firstMonday := (8 - int(jan1.Weekday())) % 7
+		date = jan1.AddDate(0, 0, firstMonday+(f.weekMonday-1)*7+(f.weekday+6)%7)
+	default:
+		if f.month < 1 || f.month > 12 {
+			return time.Time{}, fmt.Errorf("strptime: month %d out of range", f.month)
+		}
+		date = time.Date(f.year, time.Month(f.month), f.day, 0, 0, 0, 0, loc)
+		if date.Day() != f.day {
+			return time.Time{}, fmt.Errorf("strptime: day %d out of range", f.day)
+		}
+	}
+
+	if f.hour > 23 || f.minute > 59 || f.second > 60 {
+		return time.Time{}, fmt.Errorf("strptime: time %02d:%02d:%02d out of range", f.hour, f.minute, f.second)
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), f.hour, f.minute, f.second, f.nanosecond, loc), nil
+}
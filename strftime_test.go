@@ -0,0 +1,74 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStrftime(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 123456789, time.FixedZone("MST", -7*3600))
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%a %A %b %B %h", "Mon Monday Mar March Mar"},
+		{"%C %y %Y %G %g", "20 21 2021 2021 21"},
+		{"%d %e %j %m", "08  8 067 03"},
+		{"%H %I %k %l %M %S %p %P", "16 04 16  4 06 34 PM pm"},
+		{"%u %w %U %V %W", "1 1 10 10 10"},
+		{"%D|%F|%T|%R|%r", "03/08/21|2021-03-08|16:06:34|16:06|04:06:34 PM"},
+		{"%c", "Mon Mar  8 16:06:34 2021"},
+		{"%-d %-m %_H %^a %^B %3d", "8 3 16 MON MARCH 008"},
+		{"%s %N %3N %f", "1615244794 123456789 123 123456"},
+		{"%z %:z %::z %Z", "-0700 -07:00 -07:00:00 MST"},
+		{"100%% %Q", "100% %Q"},
+	}
+	for _, tt := range tests {
+		if got := Strftime(ts, tt.format); got != tt.want {
+			t.Errorf("Strftime(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestStrptime(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		want   string
+	}{
+		{"2021-03-08 16:06:34", "%Y-%m-%d %H:%M:%S", "2021-03-08T16:06:34Z"},
+		{"2021-03-08T16:06:34.5-07:00", "%Y-%m-%dT%H:%M:%S.%f%:z", "2021-03-08T16:06:34.5-07:00"},
+		{"Mon Mar  8 16:06:34 2021", "%c", "2021-03-08T16:06:34Z"},
+		{"03/08/21 04:06 pm", "%D %I:%M %p", "2021-03-08T16:06:00Z"},
+		{"8 march 2021", "%d %B %Y", "2021-03-08T00:00:00Z"},
+		{"2021 067", "%Y %j", "2021-03-08T00:00:00Z"},
+		{"2021-W10-1", "%G-W%V-%u", "2021-03-08T00:00:00Z"},
+		{"1615244794", "%s", "2021-03-08T23:06:34Z"},
+		{"2021-03-08 16:06 +0530", "%F %R %z", "2021-03-08T16:06:00+05:30"},
+	}
+	for _, tt := range tests {
+		got, err := Strptime(tt.value, tt.format)
+		if err != nil {
+			t.Errorf("Strptime(%q, %q) error: %s", tt.value, tt.format, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("Strptime(%q, %q) = %s, want %s", tt.value, tt.format, s, tt.want)
+		}
+	}
+
+	invalid := []struct {
+		value  string
+		format string
+	}{
+		{"2021-03-08", "%Y/%m/%d"},
+		{"2021-13-08", "%Y-%m-%d"},
+		{"2021-03-08 extra", "%Y-%m-%d"},
+		{"March", "%b %Y"},
+	}
+	for _, tt := range invalid {
+		if _, err := Strptime(tt.value, tt.format); err == nil {
+			t.Errorf("Strptime(%q, %q) should fail", tt.value, tt.format)
+		}
+	}
+}
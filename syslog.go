@@ -0,0 +1,140 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SyslogRFC3164 is to denote the format is a BSD syslog timestamp such as "Mar  8 16:06:34" (no year or zone)
+	SyslogRFC3164 = "Syslog (RFC 3164)"
+
+	// SyslogRFC5424 is to denote the format is an RFC 5424 syslog header such as "<34>1 2021-03-08T16:06:34.003Z host ..."
+	SyslogRFC5424 = "Syslog (RFC 5424)"
+
+	// syslogClockSkew is how far after the reference time an RFC 3164 timestamp
+	// may be before it is taken to be from the previous year
+	syslogClockSkew = 24 * time.Hour
+
+	regExSyslog3164 = `^(<\d{1,3}>)?(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) ( \d|\d\d) (\d\d):(\d\d):(\d\d)(\.\d{1,9})?( +(\S+))?`
+	regExSyslog5424 = `^<\d{1,3}>\d{1,2} (\S+)( |$)`
+)
+
+var (
+	reSyslog3164 = regexp.MustCompile(regExSyslog3164)
+	reSyslog5424 = regexp.MustCompile(regExSyslog5424)
+	reYear       = regexp.MustCompile(`^\d{4}$`)
+	reZoneYear   = regexp.MustCompile(`^ +[A-Z]{1,5} +\d{4}\b`)
+)
+
+// GetSyslogFormat determines if the provided string is a syslog timestamp or
+// starts with a syslog header (followed by the rest of the message)
+func GetSyslogFormat(dtz string) (format string) {
+	if reSyslog5424.MatchString(dtz) {
+		format = SyslogRFC5424
+	} else if matches := reSyslog3164.FindStringSubmatch(dtz); matches != nil {
+		// Mar  8 16:06:34 2021 and Mar  8 16:06:34 CET 2021 are date-times with a
+		// year rather than syslog headers
+		rest := dtz[len(matches[0])-len(matches[8]):]
+		if !reYear.MatchString(matches[9]) && !reZoneYear.MatchString(rest) {
+			format = SyslogRFC3164
+		}
+	}
+	if len(format) > 0 {
+		DebugPrintf("chronus.GetSyslogFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// ParseSyslog parses the timestamp of an RFC 3164 or RFC 5424 syslog message
+// (or a bare timestamp) using DefaultLocation and ReferenceTime for RFC 3164
+func ParseSyslog(dtz string) (t time.Time, format string, err error) {
+	format = GetSyslogFormat(dtz)
+	switch format {
+	case SyslogRFC3164:
+		t, err = ParseSyslog3164(dtz, ReferenceTime, DefaultLocation)
+	case SyslogRFC5424:
+		t, err = ParseSyslog5424(dtz)
+	default:
+		err = fmt.Errorf("invalid syslog timestamp %q", dtz)
+	}
+
+	return t, format, err
+}
+
+// ParseSyslog3164 parses a BSD syslog timestamp such as "Mar  8 16:06:34" (with
+// an optional <PRI> before it and the rest of the message after it) in loc (nil
+// is UTC). The year is the latest one that does not place the timestamp
+// after the reference time (zero is now), allowing a day of clock skew, so
+// December messages read in January fall in the previous year and old messages
+// are never placed in the future.
+func ParseSyslog3164(dtz string, reference time.Time, loc *time.Location) (t time.Time, err error) {
+	matches := reSyslog3164.FindStringSubmatch(dtz)
+	if matches == nil {
+		return t, fmt.Errorf("invalid RFC 3164 syslog timestamp %q", dtz)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	reference = reference.In(loc)
+
+	month := time.Month(nameIndex(matches[2], englishMonthsAbbr[:]) + 1)
+	day, _ := strconv.Atoi(strings.TrimSpace(matches[3]))
+	hour, _ := strconv.Atoi(matches[4])
+	minute, _ := strconv.Atoi(matches[5])
+	second, _ := strconv.Atoi(matches[6])
+	nsec := 0
+	if len(matches[7]) > 1 {
+		frac := matches[7][1:]
+		nsec, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+	if hour > 23 || minute > 59 || second > 60 {
+		return t, fmt.Errorf("invalid RFC 3164 syslog timestamp %q: time of day out of range", dtz)
+	}
+
+	latest := reference.Add(syslogClockSkew)
+	// Feb 29 may be up to 8 years back (2096 to 2104)
+	for year := latest.Year(); year >= latest.Year()-8; year-- {
+		candidate := time.Date(year, month, day, hour, minute, second, nsec, loc)
+		if candidate.Day() != day {
+			// Feb 29 outside a leap year
+			continue
+		}
+		if !candidate.After(latest) {
+			return candidate, nil
+		}
+	}
+
+	return t, fmt.Errorf("invalid RFC 3164 syslog timestamp %q: day %d is not in %s", dtz, day, month)
+}
+
+// ParseSyslog5424 parses the TIMESTAMP of an RFC 5424 syslog header such as
+// "<34>1 2021-03-08T16:06:34.003Z host app - - msg" or a bare RFC 5424 timestamp,
+// which is RFC 3339 with an upper case T and Z and at most 6 fractional digits
+func ParseSyslog5424(dtz string) (t time.Time, err error) {
+	ts := strings.TrimSpace(dtz)
+	if matches := reSyslog5424.FindStringSubmatch(dtz); matches != nil {
+		ts = matches[1]
+	}
+	if ts == "-" {
+		return t, fmt.Errorf("RFC 5424 syslog message %q has no timestamp (NILVALUE)", dtz)
+	}
+
+	digits := 0
+	if i := strings.IndexByte(ts, '.'); i >= 0 {
+		rest := ts[i+1:]
+		digits = len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+	}
+	if digits > 6 || strings.ContainsAny(ts, "tz ") {
+		return t, fmt.Errorf("invalid RFC 5424 syslog timestamp %q", ts)
+	}
+
+	return time.Parse(time.RFC3339Nano, ts)
+}
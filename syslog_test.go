@@ -0,0 +1,84 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSyslog3164(t *testing.T) {
+	tests := []struct {
+		dtz       string
+		reference time.Time
+		want      string
+	}{
+		{"Mar  8 16:06:34", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "2026-03-08T16:06:34Z"},
+		{"Dec 31 23:59:59 host app: msg", time.Date(2022, 1, 1, 0, 0, 5, 0, time.UTC), "2021-12-31T23:59:59Z"},
+		{"Jan  1 00:00:01", time.Date(2021, 12, 31, 23, 59, 0, 0, time.UTC), "2022-01-01T00:00:01Z"},
+		{"Oct 19 08:00:00", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "2026-10-19T08:00:00Z"},
+		{"Oct 20 08:00:00", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "2025-10-20T08:00:00Z"},
+		{"<34>Feb 29 12:00:00.250 host", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), "2024-02-29T12:00:00.25Z"},
+	}
+	for _, tt := range tests {
+		got, err := ParseSyslog3164(tt.dtz, tt.reference, time.UTC)
+		if err != nil {
+			t.Errorf("ParseSyslog3164(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseSyslog3164(%q, %s) = %s, want %s", tt.dtz, tt.reference.Format(time.RFC3339), s, tt.want)
+		}
+	}
+
+	for _, dtz := range []string{"Mar 32 16:06:34", "Mar  8 24:06:34", "Foo  8 16:06:34"} {
+		if _, err := ParseSyslog3164(dtz, time.Time{}, time.UTC); err == nil {
+			t.Errorf("ParseSyslog3164(%q) should fail", dtz)
+		}
+	}
+}
+
+func TestGetSyslogFormat(t *testing.T) {
+	tests := []struct {
+		dtz    string
+		format string
+	}{
+		{"Mar  8 16:06:34", SyslogRFC3164},
+		{"<13>Mar  8 16:06:34 host app[1]: msg", SyslogRFC3164},
+		{"<34>1 2021-03-08T16:06:34.003Z host app - - msg", SyslogRFC5424},
+		{"Mar  8 16:06:34 2021", ""},
+		{"Mar  8 16:06:34 CET 2021", ""},
+	}
+	for _, tt := range tests {
+		if got := GetSyslogFormat(tt.dtz); got != tt.format {
+			t.Errorf("GetSyslogFormat(%q) = %q, want %q", tt.dtz, got, tt.format)
+		}
+	}
+}
+
+func TestParseSyslog5424(t *testing.T) {
+	tests := []struct {
+		dtz  string
+		want string // empty when an error is expected
+	}{
+		{"<34>1 2021-03-08T16:06:34.003Z host app - - msg", "2021-03-08T16:06:34.003Z"},
+		{"2021-03-08T16:06:34.123456-07:00", "2021-03-08T16:06:34.123456-07:00"},
+		{"<34>1 - host app - - msg", ""},
+		{"2021-03-08T16:06:34.1234567Z", ""},
+		{"2021-03-08t16:06:34z", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseSyslog5424(tt.dtz)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseSyslog5424(%q) = %s, want an error", tt.dtz, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSyslog5424(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseSyslog5424(%q) = %s, want %s", tt.dtz, s, tt.want)
+		}
+	}
+}
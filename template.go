@@ -0,0 +1,103 @@
+package chronus
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateTime is the data passed to output templates. It embeds time.Time so
+// every time.Time method (e.g. {{.Unix}}, {{.YearDay}}, {{.Format "15:04"}}) is
+// available along with helpers that are easier to use from a template. The
+// helpers use their own names so they never change a time.Time method.
+type TemplateTime struct {
+	time.Time
+}
+
+// templateFuncs are the helper functions available to output templates
+var templateFuncs = template.FuncMap{
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"strftime": func(format string, t TemplateTime) string { return Strftime(t.Time, format) },
+	"ldml":     func(pattern string, t TemplateTime) (string, error) { return FormatLDML(t.Time, pattern) },
+}
+
+// IsTemplate reports whether the format is a text/template
+func IsTemplate(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+// ExecuteTemplate renders a text/template with t as its data
+func ExecuteTemplate(t time.Time, text string) (string, error) {
+	tmpl, err := template.New("chronus").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err = tmpl.Execute(&b, TemplateTime{t}); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// FormatAs formats the time using a named format, strftime format, LDML pattern, or Go layout
+func (tt TemplateTime) FormatAs(format string) (string, error) {
+	return Format(tt.Time, format)
+}
+
+// InZone returns the time in the named IANA location (e.g. "Asia/Tokyo")
+func (tt TemplateTime) InZone(name string) (TemplateTime, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return tt, err
+	}
+	return TemplateTime{tt.Time.In(loc)}, nil
+}
+
+// ISOWeekString returns the ISO 8601 week such as 2021-W10
+func (tt TemplateTime) ISOWeekString() string {
+	year, week := tt.Time.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ISOWeekDate returns the full ISO 8601 week date such as 2021-W10-1
+func (tt TemplateTime) ISOWeekDate() string {
+	weekday := int(tt.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	return fmt.Sprintf("%s-%d", tt.ISOWeekString(), weekday)
+}
+
+// InLocal returns the time in the local time zone
+func (tt TemplateTime) InLocal() TemplateTime {
+	return TemplateTime{tt.Time.Local()}
+}
+
+// Python returns the Python floating point timestamp
+func (tt TemplateTime) Python() float64 {
+	return PythonTimestamp(tt.Time)
+}
+
+// Quarter returns the quarter of the year (1-4)
+func (tt TemplateTime) Quarter() int {
+	return (int(tt.Month())-1)/3 + 1
+}
+
+// Strftime formats the time using a strftime format
+func (tt TemplateTime) Strftime(format string) string {
+	return Strftime(tt.Time, format)
+}
+
+// UnixMilli returns milliseconds since the UNIX Epoch (the same as time.Time.UnixMilli in Go 1.17)
+func (tt TemplateTime) UnixMilli() int64 {
+	return UnixMilli(tt.Time)
+}
+
+// InUTC returns the time in UTC
+func (tt TemplateTime) InUTC() TemplateTime {
+	return TemplateTime{tt.Time.UTC()}
+}
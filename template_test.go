@@ -0,0 +1,38 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 23, 6, 34, 500000000, time.UTC)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"{{.Unix}} {{.UnixMilli}} {{.Python}}", "1615244794 1615244794500 1.6152447945e+09"},
+		{"{{.ISOWeekString}} {{.ISOWeekDate}} Q{{.Quarter}}", "2021-W10 2021-W10-1 Q1"},
+		{`{{.Format "15:04"}} {{.YearDay}}`, "23:06 67"}, // time.Time methods are not shadowed
+		{`{{.FormatAs "%H:%M"}} {{.FormatAs "yyyy-MM-dd"}} {{.FormatAs "Kitchen"}}`, "23:06 2021-03-08 11:06PM"},
+		{`{{(.InZone "Asia/Tokyo").FormatAs "RFC3339"}}`, "2021-03-09T08:06:34+09:00"},
+		{`{{.InUTC.Hour}} {{.UTC.Hour}}`, "23 23"},
+		{`{{strftime "%Y" .}} {{ldml "MMMM" . | upper}} {{.Month.String | lower}}`, "2021 MARCH march"},
+	}
+	for _, tt := range tests {
+		got, err := ExecuteTemplate(ts, tt.text)
+		if err != nil {
+			t.Errorf("ExecuteTemplate(%q) error: %s", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ExecuteTemplate(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"{{.Unix", `{{.InZone "Mars/Olympus_Mons"}}`, "{{.NoSuchMethod}}"} {
+		if got, err := ExecuteTemplate(ts, text); err == nil {
+			t.Errorf("ExecuteTemplate(%q) = %q, want an error", text, got)
+		}
+	}
+}
@@ -96,6 +96,10 @@ func (tzloc *TimeZoneLocation) StatusUpdate(s string) (err error) {
 
 // String returns a JSON encoded version of the data
 func (tzloc *TimeZoneLocation) String() string {
+	if tzloc == nil {
+		return "<nil>"
+	}
+
 	jsonStr := "{\n"
 
 	jsonStr += `	"countryCodeAlpha2": "` + tzloc.countryCodeAlpha2 + `"` + ",\n"
@@ -174,7 +178,7 @@ func GetNationByTZAbbreviation(abbr string) (string, error) {
 		return data[0].nation, nil
 	}
 
-	err := fmt.Errorf("zone %q not found in timezone data")
+	err := fmt.Errorf("zone %q not found in timezone data", abbr)
 	return "", err
 }
 
@@ -183,7 +187,7 @@ func GetTimeZoneLocationsByTZAbbreviation(abbr string) (loc []*TimeZoneLocation,
 		return tzlocs, err
 	}
 
-	err = fmt.Errorf("zone %q not found in timezone data")
+	err = fmt.Errorf("zone %q not found in timezone data", abbr)
 	return nil, err
 }
 
@@ -192,7 +196,6 @@ func GetUSTimeZoneLocationByTZAbbreviation(abbr string) (loc *TimeZoneLocation,
 		for i, tzloc := range tzlocs {
 			DebugPrintf("chronus.GetUnitedStatesLocationByAbbreviation() | i: %d | tzloc: %q\n", i, tzloc)
 			return tzloc, err
-			break
 		}
 	}
 
@@ -205,7 +208,7 @@ func GetUSTimeZoneLocationsByTZAbbreviation(abbr string) (loc []*TimeZoneLocatio
 		return tzlocs, err
 	}
 
-	err = fmt.Errorf("zone %q not found in United States timezone data")
+	err = fmt.Errorf("zone %q not found in United States timezone data", abbr)
 	return nil, err
 }
 
@@ -0,0 +1,190 @@
+package chronus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EpochUnit is the unit of a UNIX timestamp
+type EpochUnit int
+
+const (
+	// EpochAuto detects the unit from the magnitude of the timestamp
+	EpochAuto EpochUnit = iota
+	EpochSeconds
+	EpochMilliseconds
+	EpochMicroseconds
+	EpochNanoseconds
+)
+
+// UnixTimeStampUnit overrides the detection of the unit of integer UNIX timestamps when not EpochAuto
+var UnixTimeStampUnit = EpochAuto
+
+// String returns the unit name (e.g. milliseconds)
+func (u EpochUnit) String() string {
+	switch u {
+	case EpochSeconds:
+		return "seconds"
+	case EpochMilliseconds:
+		return "milliseconds"
+	case EpochMicroseconds:
+		return "microseconds"
+	case EpochNanoseconds:
+		return "nanoseconds"
+	}
+	return "auto"
+}
+
+// ParseEpochUnit converts a unit name such as s, ms, us, µs, ns, or auto into an EpochUnit
+func ParseEpochUnit(name string) (EpochUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return EpochAuto, nil
+	case "s", "sec", "secs", "second", "seconds":
+		return EpochSeconds, nil
+	case "ms", "milli", "millis", "millisecond", "milliseconds":
+		return EpochMilliseconds, nil
+	case "us", "µs", "μs", "micro", "micros", "microsecond", "microseconds":
+		return EpochMicroseconds, nil
+	case "ns", "nano", "nanos", "nanosecond", "nanoseconds":
+		return EpochNanoseconds, nil
+	}
+	return EpochAuto, fmt.Errorf("unknown epoch unit %q", name)
+}
+
+// DetectEpochUnit guesses the unit of a UNIX timestamp from its magnitude. Up to
+// 11 digits are seconds (until the year 5138), 12 to 14 digits are milliseconds,
+// 15 to 17 digits are microseconds, and anything larger is nanoseconds. Negative
+// timestamps (before 1970) use the same ranges.
+func DetectEpochUnit(i int64) EpochUnit {
+	// compare as negative numbers since -math.MinInt64 overflows
+	if i > 0 {
+		i = -i
+	}
+	switch {
+	case i > -1e11:
+		return EpochSeconds
+	case i > -1e14:
+		return EpochMilliseconds
+	case i > -1e17:
+		return EpochMicroseconds
+	}
+	return EpochNanoseconds
+}
+
+// EpochUnitToTime converts a UNIX timestamp in the given unit into Go time.Time.
+// EpochAuto detects the unit with DetectEpochUnit.
+func EpochUnitToTime(i int64, unit EpochUnit) time.Time {
+	if unit == EpochAuto {
+		unit = DetectEpochUnit(i)
+	}
+	switch unit {
+	case EpochMilliseconds:
+		return time.Unix(i/1e3, i%1e3*1e6)
+	case EpochMicroseconds:
+		return time.Unix(i/1e6, i%1e6*1e3)
+	case EpochNanoseconds:
+		return time.Unix(0, i)
+	}
+	return time.Unix(i, 0)
+}
+
+// UnixMicro converts Go time.Time into microseconds since the UNIX Epoch
+func UnixMicro(t time.Time) int64 {
+	return t.Unix()*1e6 + int64(t.Nanosecond())/1e3
+}
+
+// UnixMicroToTime converts microseconds since the UNIX Epoch into Go time.Time
+func UnixMicroToTime(s int64) time.Time {
+	return time.Unix(s/1e6, s%1e6*1e3)
+}
+
+// UnixDecimalString returns the exact decimal UNIX timestamp of t in seconds with
+// precision fractional digits (0 to 9), truncating rather than rounding. Unlike a
+// float64 it does not lose nanoseconds, e.g. "1615219594.123456789".
+func UnixDecimalString(t time.Time, precision int) string {
+	if precision < 0 {
+		precision = 0
+	} else if precision > 9 {
+		precision = 9
+	}
+
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	sign := ""
+	if sec < 0 && nsec > 0 {
+		// -1.5 is stored as -2 seconds plus 500000000 nanoseconds
+		sec, nsec = sec+1, 1e9-nsec
+	}
+	if sec < 0 {
+		sign, sec = "-", -sec
+	} else if sec == 0 && nsec > 0 && t.Before(time.Unix(0, 0)) {
+		sign = "-"
+	}
+
+	if precision == 0 {
+		return fmt.Sprintf("%s%d", sign, sec)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, sec, fmt.Sprintf("%09d", nsec)[:precision])
+}
+
+// ParseUnixDecimal exactly converts a decimal UNIX timestamp in seconds such as
+// "1615219594.123456789" into Go time.Time. Digits past nanoseconds are truncated.
+func ParseUnixDecimal(s string) (time.Time, error) {
+	return ParseUnixDecimalUnit(s, EpochSeconds)
+}
+
+// ParseUnixDecimalUnit exactly converts a decimal UNIX timestamp in the given unit
+// into Go time.Time. EpochAuto detects the unit from the integer part.
+func ParseUnixDecimalUnit(s string, unit EpochUnit) (t time.Time, err error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	if len(whole) == 0 {
+		whole = "0"
+	}
+	if strings.Trim(whole, "0123456789") != "" || strings.Trim(frac, "0123456789") != "" {
+		return t, fmt.Errorf("invalid decimal UNIX timestamp %q", s)
+	}
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return t, err
+	}
+	if unit == EpochAuto {
+		unit = DetectEpochUnit(w)
+	}
+
+	// digits of the fraction that still count as nanoseconds for the unit
+	fracDigits := map[EpochUnit]int{EpochSeconds: 9, EpochMilliseconds: 6, EpochMicroseconds: 3, EpochNanoseconds: 0}[unit]
+	if len(frac) > fracDigits {
+		frac = frac[:fracDigits]
+	}
+	f := int64(0)
+	if fracDigits > 0 {
+		f, _ = strconv.ParseInt(frac+strings.Repeat("0", fracDigits-len(frac)), 10, 64)
+	}
+
+	var sec, nsec int64
+	switch unit {
+	case EpochMilliseconds:
+		sec, nsec = w/1e3, w%1e3*1e6+f
+	case EpochMicroseconds:
+		sec, nsec = w/1e6, w%1e6*1e3+f
+	case EpochNanoseconds:
+		sec, nsec = w/1e9, w%1e9
+	default:
+		sec, nsec = w, f
+	}
+	if negative {
+		sec, nsec = -sec, -nsec
+	}
+
+	return time.Unix(sec, nsec), nil
+}
@@ -0,0 +1,113 @@
+package chronus
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDetectEpochUnit(t *testing.T) {
+	tests := []struct {
+		i    int64
+		want EpochUnit
+	}{
+		{0, EpochSeconds},
+		{1615219594, EpochSeconds},
+		{99999999999, EpochSeconds},
+		{100000000000, EpochMilliseconds},
+		{1615219594123, EpochMilliseconds},
+		{99999999999999, EpochMilliseconds},
+		{100000000000000, EpochMicroseconds},
+		{1615219594123456, EpochMicroseconds},
+		{99999999999999999, EpochMicroseconds},
+		{100000000000000000, EpochNanoseconds},
+		{1615219594123456789, EpochNanoseconds},
+		{-99999999999, EpochSeconds},
+		{-100000000000, EpochMilliseconds},
+		{-100000000000000000, EpochNanoseconds},
+		{math.MaxInt64, EpochNanoseconds},
+		{math.MinInt64, EpochNanoseconds},
+	}
+	for _, tt := range tests {
+		if got := DetectEpochUnit(tt.i); got != tt.want {
+			t.Errorf("DetectEpochUnit(%d) = %s, want %s", tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestParseUnixTimeStampUnits(t *testing.T) {
+	want := time.Date(2021, 3, 8, 16, 6, 34, 0, time.UTC)
+	inputs := map[string]EpochUnit{
+		"1615219594":          EpochSeconds,
+		"1615219594000":       EpochMilliseconds,
+		"1615219594000000":    EpochMicroseconds,
+		"1615219594000000000": EpochNanoseconds,
+	}
+	for input, unit := range inputs {
+		p, err := ParseDetailed(input)
+		if err != nil {
+			t.Errorf("ParseDetailed(%q) error: %s", input, err)
+			continue
+		}
+		if !p.Time.Equal(want) || p.Unit != unit {
+			t.Errorf("ParseDetailed(%q) = %s in %s, want %s in %s", input, p.Time.UTC(), p.Unit, want, unit)
+		}
+	}
+
+	// a fractional timestamp is in seconds
+	got, err := Parse("1615219594.5")
+	if err != nil || !got.Equal(want.Add(500*time.Millisecond)) {
+		t.Errorf("Parse(1615219594.5) = %s, %v, want %s", got.UTC(), err, want.Add(500*time.Millisecond))
+	}
+}
+
+func TestUnixTimeStampUnitOverride(t *testing.T) {
+	defer func(u EpochUnit) { UnixTimeStampUnit = u }(UnixTimeStampUnit)
+	UnixTimeStampUnit = EpochMilliseconds
+
+	got, err := Parse("1615219594")
+	if want := time.Date(1970, 1, 19, 16, 40, 19, 594e6, time.UTC); err != nil || !got.Equal(want) {
+		t.Errorf("Parse(1615219594) in milliseconds = %s, %v, want %s", got.UTC(), err, want)
+	}
+}
+
+func TestUnixDecimal(t *testing.T) {
+	exact := time.Date(2021, 3, 8, 16, 6, 34, 123456789, time.UTC)
+
+	got, err := ParseUnixDecimal("1615219594.123456789")
+	if err != nil || !got.Equal(exact) {
+		t.Errorf("ParseUnixDecimal(1615219594.123456789) = %s, %v, want %s", got.UTC().Format(time.RFC3339Nano), err, exact.Format(time.RFC3339Nano))
+	}
+	if s := UnixDecimalString(exact, 9); s != "1615219594.123456789" {
+		t.Errorf("UnixDecimalString(%s, 9) = %s, want 1615219594.123456789", exact, s)
+	}
+	if s := UnixDecimalString(exact, 6); s != "1615219594.123456" {
+		t.Errorf("UnixDecimalString(%s, 6) = %s, want 1615219594.123456", exact, s)
+	}
+
+	p, err := ParseDetailed("1615219594.123456789")
+	if err != nil || !p.Time.Equal(exact) || p.Unit != EpochSeconds {
+		t.Errorf("ParseDetailed(1615219594.123456789) = %s in %s, %v, want %s", p.Time.UTC().Format(time.RFC3339Nano), p.Unit, err, exact.Format(time.RFC3339Nano))
+	}
+	// the fraction of milliseconds carries down to nanoseconds
+	p, err = ParseDetailed("1615219594123.456789")
+	if err != nil || !p.Time.Equal(exact) || p.Unit != EpochMilliseconds {
+		t.Errorf("ParseDetailed(1615219594123.456789) = %s in %s, %v", p.Time.UTC().Format(time.RFC3339Nano), p.Unit, err)
+	}
+
+	roundTrips := map[string]string{
+		"-1.5":        "-1.500000000",
+		"-0.25":       "-0.250000000",
+		"0.000000001": "0.000000001",
+		"1615219594":  "1615219594.000000000",
+	}
+	for s, want := range roundTrips {
+		d, err := ParseUnixDecimal(s)
+		if got := UnixDecimalString(d, 9); err != nil || got != want {
+			t.Errorf("UnixDecimalString(ParseUnixDecimal(%s)) = %s, %v, want %s", s, got, err, want)
+		}
+	}
+	if _, err := ParseUnixDecimal("16152195x4.5"); err == nil {
+		t.Error("ParseUnixDecimal(16152195x4.5) did not fail")
+	}
+}
@@ -0,0 +1,91 @@
+package chronus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// CommonLogFormat is the bracketed Apache/Nginx Common Log Format timestamp
+	CommonLogFormat = "[02/Jan/2006:15:04:05 -0700]" // [08/Mar/2021:16:06:34 -0700]
+
+	// CommonLogTime is the Common Log Format timestamp without brackets (Nginx $time_local)
+	CommonLogTime = "02/Jan/2006:15:04:05 -0700" // 08/Mar/2021:16:06:34 -0700
+
+	// ELBTimestamp is the AWS Classic, Application, and Network Load Balancer access log timestamp (always UTC)
+	ELBTimestamp = "2006-01-02T15:04:05.000000Z" // 2021-03-08T23:06:34.186641Z
+
+	// W3CDateTime is to denote the format is the separate UTC date and time fields of an IIS W3C Extended Log line
+	W3CDateTime = "IIS W3C Extended Log"
+
+	regExCommonLog = `^\[?(\d\d/(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)/\d{4}:\d\d:\d\d:\d\d [+-]\d{4})\]?`
+	regExELB       = `^((http|https|h2|grpcs|ws|wss|tls) )?(\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}Z)( |$)`
+	regExW3C       = `^(\d{4}-\d\d-\d\d)[ \t](\d\d:\d\d:\d\d(\.\d+)?)(\t| (\S+) \S)`
+	regExW3CZone   = `^([+-]\d{4}|[+-]\d\d:\d\d|[A-Z]{2,5})$`
+)
+
+var (
+	reCommonLog = regexp.MustCompile(regExCommonLog)
+	reELB       = regexp.MustCompile(regExELB)
+	reW3C       = regexp.MustCompile(regExW3C)
+	reW3CZone   = regexp.MustCompile(regExW3CZone)
+)
+
+// GetWebLogFormat determines if the provided string is a web server or load
+// balancer log timestamp, or a log line starting with one
+func GetWebLogFormat(dtz string) (format string) {
+	switch {
+	case reCommonLog.MatchString(dtz):
+		format = CommonLogTime
+		if strings.HasPrefix(dtz, "[") {
+			format = CommonLogFormat
+		}
+	case reELB.MatchString(dtz):
+		format = ELBTimestamp
+	case isW3CLine(dtz):
+		format = W3CDateTime
+	}
+	if len(format) > 0 {
+		DebugPrintf("chronus.GetWebLogFormat() | format: %q\n", format)
+	}
+
+	return format
+}
+
+// isW3CLine reports whether dtz starts with the date and time fields of a W3C
+// Extended Log line. A space separated date and time followed by an offset or
+// zone such as "2021-03-08 16:06:34 -0700 MST" is a zoned date-time instead.
+func isW3CLine(dtz string) bool {
+	matches := reW3C.FindStringSubmatch(dtz)
+	return matches != nil && !reW3CZone.MatchString(matches[5])
+}
+
+// FormatCommonLog returns t as a bracketed Common Log Format timestamp such as "[08/Mar/2021:16:06:34 -0700]"
+func FormatCommonLog(t time.Time) string {
+	return t.Format(CommonLogFormat)
+}
+
+// ParseWebLog parses a Common Log Format, AWS ELB/ALB, or IIS W3C Extended Log
+// timestamp, which may be followed by the rest of the log line. ALB lines may
+// also start with the request type (http, https, h2, ...).
+func ParseWebLog(dtz string) (t time.Time, err error) {
+	switch GetWebLogFormat(dtz) {
+	case CommonLogFormat, CommonLogTime:
+		return time.Parse(CommonLogTime, reCommonLog.FindStringSubmatch(dtz)[1])
+	case ELBTimestamp:
+		return time.Parse(ELBTimestamp, reELB.FindStringSubmatch(dtz)[3])
+	case W3CDateTime:
+		matches := reW3C.FindStringSubmatch(dtz)
+		return ParseW3CDateTime(matches[1], matches[2])
+	}
+
+	return t, fmt.Errorf("invalid web server log timestamp %q", dtz)
+}
+
+// ParseW3CDateTime combines the separate date and time fields of a W3C Extended
+// Log (IIS, CloudFront) such as "2021-03-08" and "23:06:34", which are UTC
+func ParseW3CDateTime(date, clock string) (time.Time, error) {
+	return time.Parse("2006-01-02 15:04:05", strings.TrimSpace(date)+" "+strings.TrimSpace(clock))
+}
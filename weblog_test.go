@@ -0,0 +1,77 @@
+package chronus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetWebLogFormat(t *testing.T) {
+	tests := []struct {
+		dtz    string
+		format string
+	}{
+		{"[08/Mar/2021:16:06:34 -0700]", CommonLogFormat},
+		{"08/Mar/2021:16:06:34 -0700", CommonLogTime},
+		{"2021-03-08T23:06:34.186641Z", ELBTimestamp},
+		{"h2 2021-03-08T23:06:34.186641Z app/my-lb 1.2.3.4:80", ELBTimestamp},
+		{"2021-03-08 23:06:34 W3SVC1 10.0.0.1 GET /", W3CDateTime},
+		{"2021-03-08\t23:06:34\tGET", W3CDateTime},
+		{"2021-03-08 16:06:34 -0700 MST", ""},
+		{"2021-03-08 16:06:34 -07:00 INFO started", ""},
+		{"2021-03-08 16:06:34 MST INFO started", ""},
+		{"2021-03-08T16:06:34Z", ""},
+	}
+	for _, tt := range tests {
+		if got := GetWebLogFormat(tt.dtz); got != tt.format {
+			t.Errorf("GetWebLogFormat(%q) = %q, want %q", tt.dtz, got, tt.format)
+		}
+	}
+}
+
+func TestParseWebLog(t *testing.T) {
+	tests := []struct {
+		dtz  string
+		want string
+	}{
+		{"[08/Mar/2021:16:06:34 -0700]", "2021-03-08T16:06:34-07:00"},
+		{"08/Mar/2021:16:06:34 +0100 rest", "2021-03-08T16:06:34+01:00"},
+		{"https 2021-03-08T23:06:34.186641Z app/my-lb", "2021-03-08T23:06:34.186641Z"},
+		{"2021-03-08 23:06:34 W3SVC1 10.0.0.1 GET /", "2021-03-08T23:06:34Z"},
+	}
+	for _, tt := range tests {
+		got, err := ParseWebLog(tt.dtz)
+		if err != nil {
+			t.Errorf("ParseWebLog(%q) error: %s", tt.dtz, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("ParseWebLog(%q) = %s, want %s", tt.dtz, s, tt.want)
+		}
+	}
+
+	if _, err := ParseWebLog("2021-03-08 16:06:34 -0700 MST"); err == nil {
+		t.Errorf("ParseWebLog(%q) should fail for a zoned date-time", "2021-03-08 16:06:34 -0700 MST")
+	}
+}
+
+func TestFormatWebLogNames(t *testing.T) {
+	ts := time.Date(2021, 3, 8, 16, 6, 34, 186641000, time.FixedZone("MST", -7*3600))
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"CommonLogFormat", "[08/Mar/2021:16:06:34 -0700]"},
+		{"CommonLogTime", "08/Mar/2021:16:06:34 -0700"},
+		{"ELBTimestamp", "2021-03-08T23:06:34.186641Z"},
+	}
+	for _, tt := range tests {
+		got, err := Format(ts, tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("Format(%s) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+		p, err := ParseWithFormat(tt.want, tt.name)
+		if err != nil || p.Unix() != ts.Unix() {
+			t.Errorf("ParseWithFormat(%q, %s) = %s, %v", tt.want, tt.name, p, err)
+		}
+	}
+}